@@ -0,0 +1,32 @@
+package asm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// genLargeModule returns the textual IR of a module containing n trivial
+// functions, each returning the sum of its two i32 parameters; large enough
+// for the cost of step 4b (translateTopLevelEntities) to dominate parsing.
+func genLargeModule(n int) string {
+	buf := &strings.Builder{}
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(buf, "define i32 @f%d(i32 %%a, i32 %%b) {\n\t%%r = add i32 %%a, %%b\n\tret i32 %%r\n}\n", i)
+	}
+	return buf.String()
+}
+
+// BenchmarkParseLargeModule measures end-to-end parsing and translation of a
+// module with many function bodies, exercising the concurrent
+// translateTopLevelEntities/translateFuncs path added to parallelize step
+// 4b.
+func BenchmarkParseLargeModule(b *testing.B) {
+	src := genLargeModule(2000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ParseString(src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
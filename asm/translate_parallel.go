@@ -0,0 +1,81 @@
+package asm
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/llir/ll/ast"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// translateTopLevelEntities translates the AST top-level declarations and
+// definitions indexed by gen into their IR counterparts (step 4b).
+//
+// The six substeps below only read the gen.new.* scaffolding populated in
+// step 4a and each write a disjoint part of the result (global variables,
+// aliases and ifuncs, function bodies, attribute groups, named metadata, and
+// metadata respectively), so they run concurrently. translateGlobals and
+// translateFuncs both discover blockaddress constants (the former from
+// global initializers, the latter from function bodies) and funnel them into
+// the shared gen.todo slice through addTodo, which guards every append with
+// gen.todoMu.
+func (gen *generator) translateTopLevelEntities() error {
+	g := new(errgroup.Group)
+	g.Go(gen.translateGlobals)
+	g.Go(gen.translateIndirectSymbols)
+	g.Go(gen.translateFuncs)
+	g.Go(gen.translateAttrGroupDefs)
+	g.Go(gen.translateNamedMetadataDefs)
+	g.Go(gen.translateMetadataDefs)
+	return errors.WithStack(g.Wait())
+}
+
+// addTodo appends the given blockaddress constants (to be fixed up in step
+// 7) to gen.todo, guarded by gen.todoMu so that concurrent translators (e.g.
+// translateGlobals and translateFuncs) may call it safely.
+func (gen *generator) addTodo(todo []constant.Constant) {
+	gen.todoMu.Lock()
+	gen.todo = append(gen.todo, todo...)
+	gen.todoMu.Unlock()
+}
+
+// translateFuncs translates the bodies of AST function definitions to IR,
+// sharding the work across a pool of runtime.GOMAXPROCS workers.
+func (gen *generator) translateFuncs() error {
+	g, ctx := errgroup.WithContext(context.Background())
+	work := make(chan *ast.FuncDef)
+
+	nworkers := runtime.GOMAXPROCS(0)
+	for i := 0; i < nworkers; i++ {
+		g.Go(func() error {
+			for old := range work {
+				todo, err := gen.translateFuncBody(old)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+				gen.addTodo(todo)
+			}
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		defer close(work)
+		for _, old := range gen.old.funcDefOrder {
+			select {
+			case work <- old:
+			case <-ctx.Done():
+				// The group is already tearing down (a worker returned an
+				// error); stop feeding work so this
+				// goroutine does not block forever on a channel nothing else
+				// is reading from.
+				return nil
+			}
+		}
+		return nil
+	})
+
+	return errors.WithStack(g.Wait())
+}
@@ -0,0 +1,61 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/ir/types"
+)
+
+// === [ Basic blocks ] ========================================================
+
+// BasicBlock is an LLVM IR basic block, consisting of a sequence of non-
+// branching instructions terminated by a control flow instruction (e.g. br or
+// ret).
+type BasicBlock struct {
+	// Name of the basic block; LocalIdent.
+	LocalIdent
+	// Non-branching instructions of the basic block.
+	Insts []Instruction
+	// Terminator of the basic block.
+	Term Terminator
+
+	// extra.
+
+	// Parent function of the basic block.
+	Parent *Function
+}
+
+// NewBlock appends a new basic block with the given name to the function and
+// returns it. An empty name indicates an unnamed basic block, to which an ID
+// is assigned as part of function finalization (see (*Function).AssignIDs).
+func (f *Function) NewBlock(name string) *BasicBlock {
+	block := &BasicBlock{Parent: f}
+	block.SetName(name)
+	f.Blocks = append(f.Blocks, block)
+	return block
+}
+
+// String returns the LLVM syntax representation of the basic block as a
+// type-value pair.
+func (block *BasicBlock) String() string {
+	return fmt.Sprintf("%s %s", block.Type(), block.Ident())
+}
+
+// Type returns the type of the basic block; label.
+func (block *BasicBlock) Type() types.Type {
+	return types.Label
+}
+
+// Def returns the LLVM syntax representation of the basic block definition.
+func (block *BasicBlock) Def() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%s:\n", block.Ident())
+	for _, inst := range block.Insts {
+		fmt.Fprintf(buf, "\t%s\n", inst.Def())
+	}
+	if block.Term != nil {
+		fmt.Fprintf(buf, "\t%s\n", block.Term.Def())
+	}
+	return buf.String()
+}
@@ -0,0 +1,80 @@
+// Package callgraph builds a directed call graph over an *ir.Module.
+package callgraph
+
+import (
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/value"
+)
+
+// === [ Call graphs ] =========================================================
+
+// Graph is a module-wide call graph; an edge from f to g denotes that f
+// contains a call or invoke instruction that may invoke g.
+type Graph struct {
+	nodes    []*ir.Function
+	callees  map[*ir.Function]map[*ir.Function]bool
+	callers  map[*ir.Function]map[*ir.Function]bool
+	indirect map[*ir.Function][]*IndirectCall
+}
+
+// IndirectCall records a call or invoke instruction whose callee could not be
+// statically resolved to a single function. The pointer type of the callee,
+// usable as a filter for possible callees, is not stored separately; it is
+// Callee.Type().
+type IndirectCall struct {
+	// Caller function containing the indirect call.
+	Caller *ir.Function
+	// Callee operand of the call or invoke instruction.
+	Callee value.Value
+}
+
+// New builds the call graph of m.
+func New(m *ir.Module) *Graph {
+	g := &Graph{
+		callees:  make(map[*ir.Function]map[*ir.Function]bool),
+		callers:  make(map[*ir.Function]map[*ir.Function]bool),
+		indirect: make(map[*ir.Function][]*IndirectCall),
+	}
+	for _, f := range m.Funcs {
+		g.nodes = append(g.nodes, f)
+		g.callees[f] = make(map[*ir.Function]bool)
+		g.callers[f] = make(map[*ir.Function]bool)
+	}
+	for _, f := range m.Funcs {
+		for _, callee := range calleesOf(f) {
+			g.addCallEdge(f, callee)
+		}
+	}
+	return g
+}
+
+// Nodes returns the functions of the module in the order they were declared
+// or defined.
+func (g *Graph) Nodes() []*ir.Function {
+	return g.nodes
+}
+
+// CalleesOf returns the functions that f may directly call.
+func (g *Graph) CalleesOf(f *ir.Function) []*ir.Function {
+	return mapKeys(g.callees[f])
+}
+
+// CallersOf returns the functions that may directly call f.
+func (g *Graph) CallersOf(f *ir.Function) []*ir.Function {
+	return mapKeys(g.callers[f])
+}
+
+// IndirectCallsIn returns the indirect calls made from f, i.e. those whose
+// callee could not be resolved to a single function.
+func (g *Graph) IndirectCallsIn(f *ir.Function) []*IndirectCall {
+	return g.indirect[f]
+}
+
+// mapKeys returns the keys of m.
+func mapKeys(m map[*ir.Function]bool) []*ir.Function {
+	keys := make([]*ir.Function, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,176 @@
+package callgraph
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+func containsFunc(fs []*ir.Function, f *ir.Function) bool {
+	for _, x := range fs {
+		if x == f {
+			return true
+		}
+	}
+	return false
+}
+
+// newDecl returns an external function declaration (no blocks) with the
+// given name, suitable as a callee in call graph tests.
+func newDecl(name string) *ir.Function {
+	return ir.NewFunction(name, types.Void)
+}
+
+// TestNewDirectEdge checks that a direct call from f to g is recorded as both
+// a callee edge of f and a caller edge of g.
+func TestNewDirectEdge(t *testing.T) {
+	g := newDecl("g")
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry").NewCall(g)
+	f.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f, g}})
+
+	if !containsFunc(cg.CalleesOf(f), g) {
+		t.Errorf("CalleesOf(f) = %v, want it to include g", cg.CalleesOf(f))
+	}
+	if !containsFunc(cg.CallersOf(g), f) {
+		t.Errorf("CallersOf(g) = %v, want it to include f", cg.CallersOf(g))
+	}
+	if len(cg.IndirectCallsIn(f)) != 0 {
+		t.Errorf("IndirectCallsIn(f) = %v, want none (callee resolves directly)", cg.IndirectCallsIn(f))
+	}
+}
+
+// TestNewIndirectEdge checks that a call through a function pointer parameter
+// (not statically resolvable to a single function) is recorded as an
+// indirect call rather than a callee edge.
+func TestNewIndirectEdge(t *testing.T) {
+	sig := types.NewPointer(types.NewFunc(types.Void))
+	fp := ir.NewParam("fp", sig)
+	f := ir.NewFunction("f", types.Void, fp)
+	f.NewBlock("entry").NewCall(fp)
+	f.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f}})
+
+	if len(cg.CalleesOf(f)) != 0 {
+		t.Errorf("CalleesOf(f) = %v, want none (callee is unresolvable)", cg.CalleesOf(f))
+	}
+	indirect := cg.IndirectCallsIn(f)
+	if len(indirect) != 1 || indirect[0].Callee != fp {
+		t.Errorf("IndirectCallsIn(f) = %v, want a single entry with Callee == fp", indirect)
+	}
+}
+
+// TestNewEdgeThroughAlias checks that a call through an alias of a function
+// resolves to the aliased function.
+func TestNewEdgeThroughAlias(t *testing.T) {
+	g := newDecl("g")
+	a := ir.NewAlias("a", g)
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry").NewCall(a)
+	f.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f, g}, Aliases: []*ir.Alias{a}})
+
+	if !containsFunc(cg.CalleesOf(f), g) {
+		t.Errorf("CalleesOf(f) = %v, want it to include g (resolved through alias a)", cg.CalleesOf(f))
+	}
+}
+
+// TestNewEdgeThroughIFunc checks that a call through an ifunc resolves to the
+// ifunc's resolver function (mirroring how aliases are unwrapped).
+func TestNewEdgeThroughIFunc(t *testing.T) {
+	g := newDecl("g")
+	ifunc := &ir.IFunc{Aliasee: g}
+	ifunc.SetName("ifunc")
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry").NewCall(ifunc)
+	f.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f, g}, IFuncs: []*ir.IFunc{ifunc}})
+
+	if !containsFunc(cg.CalleesOf(f), g) {
+		t.Errorf("CalleesOf(f) = %v, want it to include g (resolved through ifunc)", cg.CalleesOf(f))
+	}
+}
+
+// TestNewEdgeThroughBitCast checks that a call through a bitcast constant
+// expression of a function resolves to the underlying function.
+func TestNewEdgeThroughBitCast(t *testing.T) {
+	g := newDecl("g")
+	cast := constant.NewBitCast(g, types.NewPointer(types.I8))
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry").NewCall(cast)
+	f.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f, g}})
+
+	if !containsFunc(cg.CalleesOf(f), g) {
+		t.Errorf("CalleesOf(f) = %v, want it to include g (resolved through bitcast)", cg.CalleesOf(f))
+	}
+}
+
+// TestSCCsMutualRecursion checks that two functions calling each other form a
+// single strongly-connected component.
+func TestSCCsMutualRecursion(t *testing.T) {
+	f := ir.NewFunction("f", types.Void)
+	g := ir.NewFunction("g", types.Void)
+	f.NewBlock("entry").NewCall(g)
+	f.Blocks[0].NewRet(nil)
+	g.NewBlock("entry").NewCall(f)
+	g.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f, g}})
+	sccs := cg.SCCs()
+
+	found := false
+	for _, scc := range sccs {
+		if len(scc) == 2 && containsFunc(scc, f) && containsFunc(scc, g) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("SCCs() = %v, want a component containing both f and g", sccs)
+	}
+}
+
+// TestSCCsSelfRecursion checks that a function calling itself forms its own
+// single-element strongly-connected component.
+func TestSCCsSelfRecursion(t *testing.T) {
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry").NewCall(f)
+	f.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f}})
+	sccs := cg.SCCs()
+
+	if len(sccs) != 1 || len(sccs[0]) != 1 || sccs[0][0] != f {
+		t.Errorf("SCCs() = %v, want a single component [f]", sccs)
+	}
+}
+
+// TestReachableFromChain checks that ReachableFrom follows direct calls
+// transitively, excluding the starting function itself.
+func TestReachableFromChain(t *testing.T) {
+	h := newDecl("h")
+	g := ir.NewFunction("g", types.Void)
+	g.NewBlock("entry").NewCall(h)
+	g.Blocks[0].NewRet(nil)
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry").NewCall(g)
+	f.Blocks[0].NewRet(nil)
+
+	cg := New(&ir.Module{Funcs: []*ir.Function{f, g, h}})
+	reachable := cg.ReachableFrom(f)
+
+	if !containsFunc(reachable, g) || !containsFunc(reachable, h) {
+		t.Errorf("ReachableFrom(f) = %v, want it to include g and h", reachable)
+	}
+	if containsFunc(reachable, f) {
+		t.Errorf("ReachableFrom(f) = %v, want it to exclude f itself", reachable)
+	}
+}
@@ -0,0 +1,58 @@
+package callgraph
+
+import (
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/value"
+)
+
+// calleesOf returns the callee operand of every call and invoke instruction
+// in f.
+func calleesOf(f *ir.Function) []value.Value {
+	var callees []value.Value
+	for _, block := range f.Blocks {
+		for _, inst := range block.Insts {
+			if call, ok := inst.(*ir.InstCall); ok {
+				callees = append(callees, call.Callee)
+			}
+		}
+		if invoke, ok := block.Term.(*ir.TermInvoke); ok {
+			callees = append(callees, invoke.Invokee)
+		}
+	}
+	return callees
+}
+
+// addCallEdge resolves callee to the function(s) it may invoke and records
+// the corresponding edge(s) in g, or records an indirect call if callee
+// cannot be statically resolved.
+func (g *Graph) addCallEdge(caller *ir.Function, callee value.Value) {
+	target, ok := resolveCallee(callee)
+	if !ok {
+		g.indirect[caller] = append(g.indirect[caller], &IndirectCall{Caller: caller, Callee: callee})
+		return
+	}
+	g.callees[caller][target] = true
+	if g.callers[target] == nil {
+		g.callers[target] = make(map[*ir.Function]bool)
+	}
+	g.callers[target][caller] = true
+}
+
+// resolveCallee resolves a callee operand to the function it ultimately
+// invokes, unwrapping aliases, ifuncs and constant expressions (e.g.
+// bitcasts) along the way.
+func resolveCallee(v value.Value) (*ir.Function, bool) {
+	switch v := v.(type) {
+	case *ir.Function:
+		return v, true
+	case *ir.Alias:
+		return resolveCallee(v.Aliasee)
+	case *ir.IFunc:
+		return resolveCallee(v.Aliasee)
+	case constant.Expression:
+		return resolveCallee(v.Simplify())
+	default:
+		return nil, false
+	}
+}
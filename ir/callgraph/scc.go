@@ -0,0 +1,90 @@
+package callgraph
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// SCCs returns the strongly-connected components of the call graph, computed
+// with Tarjan's algorithm. A component of size greater than one, or a
+// single-function component with a self-edge, denotes (mutual) recursion.
+func (g *Graph) SCCs() [][]*ir.Function {
+	c := &sccCollector{
+		indices: make(map[*ir.Function]int),
+		lowlink: make(map[*ir.Function]int),
+		onStack: make(map[*ir.Function]bool),
+		callees: g.callees,
+	}
+	for _, f := range g.nodes {
+		if _, ok := c.indices[f]; !ok {
+			c.strongconnect(f)
+		}
+	}
+	return c.sccs
+}
+
+// sccCollector holds the state of a single run of Tarjan's algorithm.
+type sccCollector struct {
+	index   int
+	indices map[*ir.Function]int
+	lowlink map[*ir.Function]int
+	onStack map[*ir.Function]bool
+	stack   []*ir.Function
+	sccs    [][]*ir.Function
+	callees map[*ir.Function]map[*ir.Function]bool
+}
+
+func (c *sccCollector) strongconnect(v *ir.Function) {
+	c.indices[v] = c.index
+	c.lowlink[v] = c.index
+	c.index++
+	c.stack = append(c.stack, v)
+	c.onStack[v] = true
+
+	for w := range c.callees[v] {
+		if _, ok := c.indices[w]; !ok {
+			c.strongconnect(w)
+			if c.lowlink[w] < c.lowlink[v] {
+				c.lowlink[v] = c.lowlink[w]
+			}
+		} else if c.onStack[w] {
+			if c.indices[w] < c.lowlink[v] {
+				c.lowlink[v] = c.indices[w]
+			}
+		}
+	}
+
+	if c.lowlink[v] == c.indices[v] {
+		var scc []*ir.Function
+		for {
+			n := len(c.stack) - 1
+			w := c.stack[n]
+			c.stack = c.stack[:n]
+			c.onStack[w] = false
+			scc = append(scc, w)
+			if w == v {
+				break
+			}
+		}
+		c.sccs = append(c.sccs, scc)
+	}
+}
+
+// ReachableFrom returns the functions transitively reachable from f through
+// direct calls, not including f itself.
+func (g *Graph) ReachableFrom(f *ir.Function) []*ir.Function {
+	visited := map[*ir.Function]bool{f: true}
+	var order []*ir.Function
+	queue := []*ir.Function{f}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for callee := range g.callees[n] {
+			if !visited[callee] {
+				visited[callee] = true
+				order = append(order, callee)
+				queue = append(queue, callee)
+			}
+		}
+	}
+	return order
+}
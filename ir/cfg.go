@@ -0,0 +1,43 @@
+package ir
+
+// === [ Control flow graph ] ===================================================
+
+// Successors returns the successor basic blocks of b, as determined by the
+// terminator of b. Only br terminators (unconditional and conditional) are
+// considered; a block ending in any other terminator (e.g. ret or invoke) is
+// reported as having no successors.
+func (f *Function) Successors(b *BasicBlock) []*BasicBlock {
+	switch term := b.Term.(type) {
+	case *TermBr:
+		return []*BasicBlock{term.Target}
+	case *TermCondBr:
+		return []*BasicBlock{term.TargetTrue, term.TargetFalse}
+	default:
+		return nil
+	}
+}
+
+// Predecessors returns the predecessor basic blocks of b within f, computed
+// by scanning the terminators of f.Blocks.
+func (f *Function) Predecessors(b *BasicBlock) []*BasicBlock {
+	var preds []*BasicBlock
+	for _, p := range f.Blocks {
+		for _, s := range f.Successors(p) {
+			if s == b {
+				preds = append(preds, p)
+				break
+			}
+		}
+	}
+	return preds
+}
+
+// removeBlock removes b from f.Blocks.
+func (f *Function) removeBlock(b *BasicBlock) {
+	for i, x := range f.Blocks {
+		if x == b {
+			f.Blocks = append(f.Blocks[:i], f.Blocks[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package ir
+
+// MergeBlocks collapses a block into its sole predecessor wherever the
+// predecessor has exactly one successor, the block has exactly one
+// predecessor, and the block contains no phi nodes.
+func (f *Function) MergeBlocks() {
+	for {
+		merged := false
+		for _, v := range f.Blocks {
+			preds := f.Predecessors(v)
+			if len(preds) != 1 {
+				continue
+			}
+			u := preds[0]
+			if u == v || len(f.Successors(u)) != 1 || hasPhi(v) {
+				continue
+			}
+			f.mergeInto(u, v)
+			merged = true
+			break // f.Blocks was mutated; restart the scan.
+		}
+		if !merged {
+			return
+		}
+	}
+}
+
+// hasPhi reports whether b contains any phi instructions.
+func hasPhi(b *BasicBlock) bool {
+	for _, inst := range b.Insts {
+		if _, ok := inst.(*InstPhi); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeInto appends v's instructions and terminator onto u, rewrites phi
+// incoming entries of v's successors that referenced v to reference u
+// instead, and removes v from f.
+func (f *Function) mergeInto(u, v *BasicBlock) {
+	succs := f.Successors(v)
+	u.Insts = append(u.Insts, v.Insts...)
+	u.Term = v.Term
+	for _, s := range succs {
+		for _, inst := range s.Insts {
+			phi, ok := inst.(*InstPhi)
+			if !ok {
+				continue
+			}
+			for _, inc := range phi.Incs {
+				if inc.Pred == v {
+					inc.Pred = u
+				}
+			}
+		}
+	}
+	f.removeBlock(v)
+}
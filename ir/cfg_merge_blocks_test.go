@@ -0,0 +1,99 @@
+package ir
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+// TestMergeBlocksStraightLine builds:
+//
+//	entry: br mid
+//	mid:   %a = add x, x; br exit
+//	exit:  ret %a
+//
+// and checks that mid and then exit are merged into entry, leaving a
+// single-block function whose entry contains the add and whose terminator is
+// exit's ret.
+func TestMergeBlocksStraightLine(t *testing.T) {
+	x := NewParam("x", types.I32)
+	f := NewFunction("f", types.I32, x)
+	entry := f.NewBlock("entry")
+	mid := f.NewBlock("mid")
+	exit := f.NewBlock("exit")
+	entry.NewBr(mid)
+	a := mid.NewAdd(x, x)
+	mid.NewBr(exit)
+	exit.NewRet(a)
+
+	f.MergeBlocks()
+
+	if len(f.Blocks) != 1 {
+		t.Fatalf("len(f.Blocks) = %d, want 1 (mid and exit merged into entry)", len(f.Blocks))
+	}
+	if f.Blocks[0] != entry {
+		t.Fatalf("f.Blocks[0] = %v, want entry", f.Blocks[0])
+	}
+	found := false
+	for _, inst := range entry.Insts {
+		if inst == a {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("entry.Insts does not contain mid's add instruction after merge")
+	}
+	if entry.Term != exit.Term {
+		t.Error("entry.Term != exit.Term, want exit's terminator moved onto entry")
+	}
+}
+
+// TestMergeBlocksRewritesSuccessorPhi builds:
+//
+//	entry: br mid
+//	mid:   br exit
+//	exit:  %p = phi [0, mid]; ret %p
+//
+// and checks that after mid is merged into entry, exit's phi incoming block
+// is rewritten from mid to entry (the bug fixed in mergeInto: forgetting this
+// rewrite leaves a phi referencing a predecessor that no longer exists).
+func TestMergeBlocksRewritesSuccessorPhi(t *testing.T) {
+	f := NewFunction("f", types.I32)
+	entry := f.NewBlock("entry")
+	mid := f.NewBlock("mid")
+	exit := f.NewBlock("exit")
+	entry.NewBr(mid)
+	mid.NewBr(exit)
+	zero := constant.NewInt(types.I32, 0)
+	phi := exit.NewPhi(NewIncoming(zero, mid))
+	exit.NewRet(phi)
+
+	f.MergeBlocks()
+
+	if len(phi.Incs) != 1 {
+		t.Fatalf("len(phi.Incs) = %d, want 1", len(phi.Incs))
+	}
+	if phi.Incs[0].Pred != entry {
+		t.Errorf("phi.Incs[0].Pred = %v, want entry (mid was merged into entry)", phi.Incs[0].Pred)
+	}
+}
+
+// TestMergeBlocksNoOpWithPhi checks that a block with a phi is never merged
+// into its predecessor, even when it otherwise qualifies (single predecessor
+// with a single successor).
+func TestMergeBlocksNoOpWithPhi(t *testing.T) {
+	f := NewFunction("f", types.I32)
+	entry := f.NewBlock("entry")
+	mid := f.NewBlock("mid")
+	entry.NewBr(mid)
+	zero := constant.NewInt(types.I32, 0)
+	phi := mid.NewPhi(NewIncoming(zero, entry))
+	mid.NewRet(phi)
+
+	f.MergeBlocks()
+
+	if len(f.Blocks) != 2 {
+		t.Errorf("len(f.Blocks) = %d, want 2 (mid has a phi, must not be merged)", len(f.Blocks))
+	}
+}
@@ -0,0 +1,63 @@
+package ir
+
+// SplitCriticalEdges inserts a new empty block along every critical edge of
+// f: an edge from a block u with more than one successor into a block v with
+// more than one predecessor. The terminator of u and any phi entries in v
+// incoming from u are rewritten to reference the new block instead.
+func (f *Function) SplitCriticalEdges() {
+	type edge struct {
+		u, v *BasicBlock
+	}
+	// Collect edges to split before mutating f.Blocks.
+	var critical []edge
+	for _, u := range f.Blocks {
+		if len(f.Successors(u)) <= 1 {
+			continue
+		}
+		seen := make(map[*BasicBlock]bool)
+		for _, v := range f.Successors(u) {
+			// A conditional br with both targets equal lists v twice; splitEdge
+			// rewrites both targets at once, so record the edge only once.
+			if seen[v] {
+				continue
+			}
+			seen[v] = true
+			if len(f.Predecessors(v)) > 1 {
+				critical = append(critical, edge{u: u, v: v})
+			}
+		}
+	}
+	for _, e := range critical {
+		f.splitEdge(e.u, e.v)
+	}
+}
+
+// splitEdge inserts a new empty block along the edge from u to v.
+func (f *Function) splitEdge(u, v *BasicBlock) {
+	split := f.NewBlock("")
+	split.NewBr(v)
+	switch term := u.Term.(type) {
+	case *TermBr:
+		if term.Target == v {
+			term.Target = split
+		}
+	case *TermCondBr:
+		if term.TargetTrue == v {
+			term.TargetTrue = split
+		}
+		if term.TargetFalse == v {
+			term.TargetFalse = split
+		}
+	}
+	for _, inst := range v.Insts {
+		phi, ok := inst.(*InstPhi)
+		if !ok {
+			continue
+		}
+		for _, inc := range phi.Incs {
+			if inc.Pred == u {
+				inc.Pred = split
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package ir
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+// TestSplitCriticalEdgesSplitsAndRewritesPhi builds:
+//
+//	entry: br cond, thenB, elseB   (thenB has two successors: a critical edge)
+//	thenB: br cond2, end, other
+//	elseB: br end
+//	other: ret void
+//	end:   %p = phi [0, thenB], [1, elseB]; ret void
+//
+// so the thenB->end edge is critical (thenB has >1 successor, end has >1
+// predecessor), and checks that a new block is spliced in along it, with
+// thenB's terminator and end's phi rewritten to reference the split block
+// instead of thenB.
+func TestSplitCriticalEdgesSplitsAndRewritesPhi(t *testing.T) {
+	cond := NewParam("cond", types.I1)
+	cond2 := NewParam("cond2", types.I1)
+	f := NewFunction("f", types.Void, cond, cond2)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("thenB")
+	elseB := f.NewBlock("elseB")
+	other := f.NewBlock("other")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewCondBr(cond2, end, other)
+	elseB.NewBr(end)
+	other.NewRet(nil)
+	zero := constant.NewInt(types.I32, 0)
+	one := constant.NewInt(types.I32, 1)
+	phi := end.NewPhi(NewIncoming(zero, thenB), NewIncoming(one, elseB))
+	end.NewRet(nil)
+
+	numBlocksBefore := len(f.Blocks)
+	f.SplitCriticalEdges()
+
+	if len(f.Blocks) != numBlocksBefore+1 {
+		t.Fatalf("len(f.Blocks) = %d, want %d (one split block inserted)", len(f.Blocks), numBlocksBefore+1)
+	}
+
+	term, ok := thenB.Term.(*TermCondBr)
+	if !ok {
+		t.Fatalf("thenB.Term = %T, want *TermCondBr", thenB.Term)
+	}
+	if term.TargetTrue == end {
+		t.Error("thenB's true target still points directly at end, want it rewritten to the split block")
+	}
+	split := term.TargetTrue
+	if split == nil || split == end {
+		t.Fatalf("thenB's true target = %v, want a new split block", split)
+	}
+
+	var incFromSplit, incFromThenB bool
+	for _, inc := range phi.Incs {
+		if inc.Pred == split {
+			incFromSplit = true
+		}
+		if inc.Pred == thenB {
+			incFromThenB = true
+		}
+	}
+	if !incFromSplit {
+		t.Error("end's phi has no incoming entry from the split block")
+	}
+	if incFromThenB {
+		t.Error("end's phi still has an incoming entry from thenB, want it rewritten to the split block")
+	}
+}
+
+// TestSplitCriticalEdgesNoOpWhenNotCritical checks that an ordinary
+// (non-critical) edge is left untouched.
+func TestSplitCriticalEdgesNoOpWhenNotCritical(t *testing.T) {
+	cond := NewParam("cond", types.I1)
+	f := NewFunction("f", types.Void, cond)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("thenB")
+	elseB := f.NewBlock("elseB")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewRet(nil)
+	elseB.NewRet(nil)
+
+	numBlocksBefore := len(f.Blocks)
+	f.SplitCriticalEdges()
+
+	if len(f.Blocks) != numBlocksBefore {
+		t.Errorf("len(f.Blocks) = %d, want %d (no critical edges)", len(f.Blocks), numBlocksBefore)
+	}
+}
@@ -0,0 +1,41 @@
+package ir
+
+import (
+	"github.com/llir/llvm/ir/types"
+)
+
+// Unify rewrites f so that it has a single return, by routing every ret
+// terminator to a synthesized exit block through an unconditional branch; the
+// exit block returns a phi over the values returned by the original ret
+// terminators (or nothing, for a void-returning function). Unify is a no-op
+// if f has at most one return.
+func (f *Function) Unify() {
+	var rets []*BasicBlock
+	for _, b := range f.Blocks {
+		if _, ok := b.Term.(*TermRet); ok {
+			rets = append(rets, b)
+		}
+	}
+	if len(rets) <= 1 {
+		return
+	}
+
+	exit := f.NewBlock("")
+	isVoid := f.Sig.RetType.Equal(types.Void)
+	var phi *InstPhi
+	if !isVoid {
+		phi = exit.NewPhi()
+	}
+	for _, b := range rets {
+		ret := b.Term.(*TermRet)
+		if !isVoid {
+			phi.Incs = append(phi.Incs, NewIncoming(ret.X, b))
+		}
+		b.NewBr(exit)
+	}
+	if isVoid {
+		exit.NewRet(nil)
+	} else {
+		exit.NewRet(phi)
+	}
+}
@@ -0,0 +1,98 @@
+package ir
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir/types"
+)
+
+// TestUnifyMultipleReturns builds:
+//
+//	entry: br cond, thenB, elseB
+//	thenB: ret x
+//	elseB: ret y
+//
+// and checks that Unify routes both returns through a single synthesized
+// exit block that returns a phi over x and y.
+func TestUnifyMultipleReturns(t *testing.T) {
+	cond := NewParam("cond", types.I1)
+	x := NewParam("x", types.I32)
+	y := NewParam("y", types.I32)
+	f := NewFunction("f", types.I32, cond, x, y)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("thenB")
+	elseB := f.NewBlock("elseB")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewRet(x)
+	elseB.NewRet(y)
+
+	f.Unify()
+
+	var rets []*BasicBlock
+	for _, b := range f.Blocks {
+		if _, ok := b.Term.(*TermRet); ok {
+			rets = append(rets, b)
+		}
+	}
+	if len(rets) != 1 {
+		t.Fatalf("function has %d blocks terminated by ret after Unify, want 1", len(rets))
+	}
+	exit := rets[0]
+	ret := exit.Term.(*TermRet)
+	phi, ok := ret.X.(*InstPhi)
+	if !ok {
+		t.Fatalf("ret.X = %T, want *InstPhi", ret.X)
+	}
+	if len(phi.Incs) != 2 {
+		t.Fatalf("len(phi.Incs) = %d, want 2", len(phi.Incs))
+	}
+	for _, b := range []*BasicBlock{thenB, elseB} {
+		if br, ok := b.Term.(*TermBr); !ok || br.Target != exit {
+			t.Errorf("%s.Term = %v, want an unconditional branch to the exit block", b.Ident(), b.Term)
+		}
+	}
+}
+
+// TestUnifyVoidReturns checks that Unify merges multiple void returns into a
+// single exit block that returns void, without constructing a phi.
+func TestUnifyVoidReturns(t *testing.T) {
+	cond := NewParam("cond", types.I1)
+	f := NewFunction("f", types.Void, cond)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("thenB")
+	elseB := f.NewBlock("elseB")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewRet(nil)
+	elseB.NewRet(nil)
+
+	f.Unify()
+
+	var rets []*BasicBlock
+	for _, b := range f.Blocks {
+		if _, ok := b.Term.(*TermRet); ok {
+			rets = append(rets, b)
+		}
+	}
+	if len(rets) != 1 {
+		t.Fatalf("function has %d blocks terminated by ret after Unify, want 1", len(rets))
+	}
+	if rets[0].Term.(*TermRet).X != nil {
+		t.Error("exit block's ret has a non-nil value, want nil for a void-returning function")
+	}
+}
+
+// TestUnifyNoOpWithSingleReturn checks that Unify leaves a function with a
+// single return unchanged.
+func TestUnifyNoOpWithSingleReturn(t *testing.T) {
+	x := NewParam("x", types.I32)
+	f := NewFunction("f", types.I32, x)
+	entry := f.NewBlock("entry")
+	entry.NewRet(x)
+
+	numBlocksBefore := len(f.Blocks)
+	f.Unify()
+
+	if len(f.Blocks) != numBlocksBefore {
+		t.Errorf("len(f.Blocks) = %d, want %d (no-op for a single return)", len(f.Blocks), numBlocksBefore)
+	}
+}
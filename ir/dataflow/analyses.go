@@ -0,0 +1,112 @@
+package dataflow
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// === [ Canonical analyses ] ===================================================
+
+// LiveVars is live-variable analysis: a backward may-analysis computing, at
+// every program point, the set of SSA values that may be used along some
+// path before being redefined.
+type LiveVars struct{}
+
+// Run computes live-variable analysis over f.
+func (LiveVars) Run(f *ir.Function) *Result {
+	transfer := func(elem interface{}, out Set) Set {
+		in := out.(ValueSet)
+		if d, ok := def(elem); ok {
+			in = in.Remove(d)
+		}
+		for _, u := range uses(elem) {
+			in = in.Add(u)
+		}
+		return in
+	}
+	prob := Problem{
+		Direction: Backward,
+		Meet:      unionMeet,
+		Transfer:  transfer,
+		Init:      ValueSet{},
+		Entry:     ValueSet{},
+	}
+	return Solve(f, prob)
+}
+
+// ReachingDefs is reaching-definitions analysis: a forward may-analysis
+// computing, at every program point, the set of definitions that may reach it
+// along some path.
+type ReachingDefs struct{}
+
+// Run computes reaching-definitions analysis over f.
+func (ReachingDefs) Run(f *ir.Function) *Result {
+	transfer := func(elem interface{}, in Set) Set {
+		out := in.(ValueSet)
+		if d, ok := def(elem); ok {
+			out = out.Add(d)
+		}
+		return out
+	}
+	prob := Problem{
+		Direction: Forward,
+		Meet:      unionMeet,
+		Transfer:  transfer,
+		Init:      ValueSet{},
+		Entry:     ValueSet{},
+	}
+	return Solve(f, prob)
+}
+
+// AvailExprs is available-expressions analysis: a forward must-analysis
+// computing, at every program point, the set of expressions already computed
+// along every path reaching it (and not since invalidated).
+type AvailExprs struct{}
+
+// Run computes available-expressions analysis over f.
+func (AvailExprs) Run(f *ir.Function) *Result {
+	top := allExprs(f)
+	transfer := func(elem interface{}, in Set) Set {
+		out := in.(ExprSet)
+		if inst, ok := elem.(ir.Instruction); ok {
+			if k, ok := exprKey(inst); ok {
+				out = out.Add(k)
+			}
+		}
+		return out
+	}
+	prob := Problem{
+		Direction: Forward,
+		Meet:      intersectMeet,
+		Transfer:  transfer,
+		Init:      top,
+		Entry:     ExprSet{},
+	}
+	return Solve(f, prob)
+}
+
+// VeryBusyExprs is very-busy-expressions analysis: a backward must-analysis
+// computing, at every program point, the set of expressions that are
+// recomputed along every path leaving it.
+type VeryBusyExprs struct{}
+
+// Run computes very-busy-expressions analysis over f.
+func (VeryBusyExprs) Run(f *ir.Function) *Result {
+	top := allExprs(f)
+	transfer := func(elem interface{}, out Set) Set {
+		in := out.(ExprSet)
+		if inst, ok := elem.(ir.Instruction); ok {
+			if k, ok := exprKey(inst); ok {
+				in = in.Add(k)
+			}
+		}
+		return in
+	}
+	prob := Problem{
+		Direction: Backward,
+		Meet:      intersectMeet,
+		Transfer:  transfer,
+		Init:      top,
+		Entry:     ExprSet{},
+	}
+	return Solve(f, prob)
+}
@@ -0,0 +1,215 @@
+package dataflow
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+func hasValue(s ValueSet, v value.Value) bool {
+	return s[v]
+}
+
+// TestLiveVarsDiamond builds:
+//
+//	entry: br cond, then, else
+//	then:  %a = add x, y; br end
+//	else:  %b = add x, y; br end
+//	end:   %d = phi [%a, then], [%b, else]; ret %d
+//
+// and checks live-variable analysis at a couple of well-defined points.
+func TestLiveVarsDiamond(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("diamond", types.I32, cond, x, y)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	a := thenB.NewAdd(x, y)
+	thenB.NewBr(end)
+	b := elseB.NewAdd(x, y)
+	elseB.NewBr(end)
+	phi := end.NewPhi(ir.NewIncoming(a, thenB), ir.NewIncoming(b, elseB))
+	end.NewRet(phi)
+
+	res := (LiveVars{}).Run(f)
+
+	in := res.In(a).(ValueSet)
+	if !hasValue(in, x) || !hasValue(in, y) {
+		t.Errorf("In(a) = %v, want it to contain x and y", in)
+	}
+
+	out := res.Out(end.Term).(ValueSet)
+	if len(out) != 0 {
+		t.Errorf("Out(ret) = %v, want empty (no uses after the function returns)", out)
+	}
+}
+
+// TestReachingDefsStraightLine builds:
+//
+//	entry: %a = add x, y; br exit
+//	exit:  %b = add a, a; ret b
+//
+// and checks that reaching-definitions analysis propagates %a forward across
+// the block boundary and accumulates %b after it is defined.
+func TestReachingDefsStraightLine(t *testing.T) {
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("straight", types.I32, x, y)
+	entry := f.NewBlock("entry")
+	exit := f.NewBlock("exit")
+	a := entry.NewAdd(x, y)
+	entry.NewBr(exit)
+	b := exit.NewAdd(a, a)
+	exit.NewRet(b)
+
+	res := (ReachingDefs{}).Run(f)
+
+	in := res.In(b).(ValueSet)
+	if len(in) != 1 || !hasValue(in, a) {
+		t.Errorf("In(b) = %v, want {a}", in)
+	}
+
+	inRet := res.In(exit.Term).(ValueSet)
+	if !hasValue(inRet, a) || !hasValue(inRet, b) {
+		t.Errorf("In(ret) = %v, want it to contain a and b", inRet)
+	}
+}
+
+// TestAvailExprsDiamond builds:
+//
+//	entry: br cond, then, else
+//	then:  %a = add x, y; br end
+//	else:  %b = add x, y; br end
+//	end:   %c = add x, y
+//
+// and checks that add x, y is available at end (computed on every path
+// reaching it), exercising the intersectMeet confluence of a must-analysis.
+func TestAvailExprsDiamond(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("diamond", types.I32, cond, x, y)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewAdd(x, y)
+	thenB.NewBr(end)
+	elseB.NewAdd(x, y)
+	elseB.NewBr(end)
+	c := end.NewAdd(x, y)
+	end.NewRet(c)
+
+	res := (AvailExprs{}).Run(f)
+
+	key, ok := exprKey(c)
+	if !ok {
+		t.Fatalf("exprKey(c) = (_, false), want true")
+	}
+	in := res.In(c).(ExprSet)
+	if !in[key] {
+		t.Errorf("In(c) = %v, want it to contain %q (computed on both incoming paths)", in, key)
+	}
+}
+
+// TestAvailExprsNotAvailableOnAllPaths builds the same diamond, but with
+// add x, y computed on only one of the two incoming paths, and checks that it
+// is therefore not available at end.
+func TestAvailExprsNotAvailableOnAllPaths(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("diamond", types.I32, cond, x, y)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewAdd(x, y)
+	thenB.NewBr(end)
+	// elseB reaches end without computing add x, y.
+	elseB.NewBr(end)
+	c := end.NewAdd(x, y)
+	end.NewRet(c)
+
+	res := (AvailExprs{}).Run(f)
+
+	key, ok := exprKey(c)
+	if !ok {
+		t.Fatalf("exprKey(c) = (_, false), want true")
+	}
+	in := res.In(c).(ExprSet)
+	if in[key] {
+		t.Errorf("In(c) = %v, want it to not contain %q (not computed on the else path)", in, key)
+	}
+}
+
+// TestVeryBusyExprsDiamond builds:
+//
+//	entry: br cond, then, else
+//	then:  %a = add x, y; ret %a
+//	else:  %b = add x, y; ret %b
+//
+// and checks that add x, y is very busy at entry (recomputed on every path
+// leaving it), exercising the backward confluence of a must-analysis.
+func TestVeryBusyExprsDiamond(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("diamond", types.I32, cond, x, y)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	entry.NewCondBr(cond, thenB, elseB)
+	a := thenB.NewAdd(x, y)
+	thenB.NewRet(a)
+	b := elseB.NewAdd(x, y)
+	elseB.NewRet(b)
+
+	res := (VeryBusyExprs{}).Run(f)
+
+	key, ok := exprKey(a)
+	if !ok {
+		t.Fatalf("exprKey(a) = (_, false), want true")
+	}
+	out := res.Out(entry.Term).(ExprSet)
+	if !out[key] {
+		t.Errorf("Out(entry.Term) = %v, want it to contain %q (recomputed on both outgoing paths)", out, key)
+	}
+}
+
+// TestVeryBusyExprsNotBusyOnAllPaths builds the same diamond, but with
+// add x, y recomputed on only one of the two outgoing paths, and checks that
+// it is therefore not very busy at entry.
+func TestVeryBusyExprsNotBusyOnAllPaths(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("diamond", types.I32, cond, x, y)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	entry.NewCondBr(cond, thenB, elseB)
+	a := thenB.NewAdd(x, y)
+	thenB.NewRet(a)
+	// elseB leaves the function without recomputing add x, y.
+	elseB.NewRet(x)
+
+	res := (VeryBusyExprs{}).Run(f)
+
+	key, ok := exprKey(a)
+	if !ok {
+		t.Fatalf("exprKey(a) = (_, false), want true")
+	}
+	out := res.Out(entry.Term).(ExprSet)
+	if out[key] {
+		t.Errorf("Out(entry.Term) = %v, want it to not contain %q (not recomputed on the else path)", out, key)
+	}
+}
@@ -0,0 +1,184 @@
+// Package dataflow provides a reusable monotone data-flow framework over
+// *ir.Function, along with a handful of canonical analyses built on top of
+// it.
+package dataflow
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// === [ Monotone data-flow framework ] ========================================
+
+// Direction specifies the direction in which a data-flow problem propagates
+// information through a control flow graph.
+type Direction int
+
+const (
+	// Forward propagates information from entry to exit (e.g. reaching
+	// definitions, available expressions).
+	Forward Direction = iota
+	// Backward propagates information from exit to entry (e.g. live
+	// variables, very busy expressions).
+	Backward
+)
+
+// Set is a data-flow lattice element; in practice the set of values or
+// expressions holding at a program point.
+type Set interface {
+	// Union returns the union of s and other.
+	Union(other Set) Set
+	// Intersect returns the intersection of s and other.
+	Intersect(other Set) Set
+	// Equal reports whether s and other represent the same set.
+	Equal(other Set) bool
+	// Clone returns a copy of s.
+	Clone() Set
+}
+
+// Meet combines the data-flow values flowing into a confluence point; Union
+// for may-analyses, Intersect for must-analyses.
+type Meet func(a, b Set) Set
+
+// Transfer computes the data-flow value on the far side of elem (an
+// ir.Instruction or ir.Terminator) given the value on the near side, where
+// "near"/"far" are entry/exit for a Forward problem and exit/entry for a
+// Backward one.
+type Transfer func(elem interface{}, val Set) Set
+
+// Problem specifies a monotone data-flow problem.
+type Problem struct {
+	// Direction of propagation.
+	Direction Direction
+	// Meet operator applied at confluence points.
+	Meet Meet
+	// Transfer function applied across each instruction/terminator.
+	Transfer Transfer
+	// Init is the data-flow value blocks are seeded with before the first
+	// iteration.
+	Init Set
+	// Entry is the boundary value at the entry block (Forward) or at every
+	// exit block (Backward).
+	Entry Set
+}
+
+// Result holds the fixpoint solution of a data-flow problem: the data-flow
+// value immediately before (In) and after (Out) every instruction and
+// terminator of the analyzed function.
+type Result struct {
+	in  map[interface{}]Set
+	out map[interface{}]Set
+}
+
+// In returns the data-flow value immediately before elem (an ir.Instruction
+// or ir.Terminator).
+func (r *Result) In(elem interface{}) Set {
+	return r.in[elem]
+}
+
+// Out returns the data-flow value immediately after elem (an ir.Instruction
+// or ir.Terminator).
+func (r *Result) Out(elem interface{}) Set {
+	return r.out[elem]
+}
+
+// Solve iterates prob to a fixpoint over the control flow graph of f using a
+// work-list keyed on basic blocks (successors are revisited on a Forward
+// problem, predecessors on a Backward one), and returns the per-instruction
+// result.
+func Solve(f *ir.Function, prob Problem) *Result {
+	res := &Result{in: make(map[interface{}]Set), out: make(map[interface{}]Set)}
+	blocks := f.Blocks
+	if len(blocks) == 0 {
+		return res
+	}
+	var graphPred, graphSucc func(*ir.BasicBlock) []*ir.BasicBlock
+	if prob.Direction == Forward {
+		graphPred = f.Predecessors
+		graphSucc = f.Successors
+	} else {
+		graphPred = f.Successors
+		graphSucc = f.Predecessors
+	}
+
+	out := make(map[*ir.BasicBlock]Set, len(blocks))
+	for _, b := range blocks {
+		out[b] = prob.Init
+	}
+
+	worklist := make([]*ir.BasicBlock, len(blocks))
+	copy(worklist, blocks)
+	queued := make(map[*ir.BasicBlock]bool, len(blocks))
+	for _, b := range blocks {
+		queued[b] = true
+	}
+
+	for len(worklist) > 0 {
+		b := worklist[0]
+		worklist = worklist[1:]
+		queued[b] = false
+
+		preds := graphPred(b)
+		var in Set
+		if len(preds) == 0 {
+			in = prob.Entry
+		} else {
+			in = out[preds[0]]
+			for _, p := range preds[1:] {
+				in = prob.Meet(in, out[p])
+			}
+		}
+
+		newOut := transferBlock(b, in, prob, res)
+		if !out[b].Equal(newOut) {
+			out[b] = newOut
+			for _, s := range graphSucc(b) {
+				if !queued[s] {
+					queued[s] = true
+					worklist = append(worklist, s)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// transferBlock applies prob.Transfer across the instructions and terminator
+// of b, in the order appropriate to prob.Direction, recording the
+// per-instruction In/Out values into res, and returns the value flowing out
+// the far side of the block.
+func transferBlock(b *ir.BasicBlock, val Set, prob Problem, res *Result) Set {
+	for _, elem := range blockElems(b, prob.Direction) {
+		if prob.Direction == Forward {
+			res.in[elem] = val
+			val = prob.Transfer(elem, val)
+			res.out[elem] = val
+		} else {
+			res.out[elem] = val
+			val = prob.Transfer(elem, val)
+			res.in[elem] = val
+		}
+	}
+	return val
+}
+
+// blockElems returns the instructions and terminator of b in the order they
+// should be visited for the given direction.
+func blockElems(b *ir.BasicBlock, dir Direction) []interface{} {
+	elems := make([]interface{}, 0, len(b.Insts)+1)
+	if dir == Forward {
+		for _, inst := range b.Insts {
+			elems = append(elems, inst)
+		}
+		if b.Term != nil {
+			elems = append(elems, b.Term)
+		}
+		return elems
+	}
+	if b.Term != nil {
+		elems = append(elems, b.Term)
+	}
+	for i := len(b.Insts) - 1; i >= 0; i-- {
+		elems = append(elems, b.Insts[i])
+	}
+	return elems
+}
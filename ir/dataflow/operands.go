@@ -0,0 +1,100 @@
+package dataflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// def returns the value defined by elem, if any. Instructions without a
+// result (e.g. store, which does not implement value.Value) or with void
+// result (e.g. a call to a void-returning function) define no value.
+func def(elem interface{}) (value.Value, bool) {
+	if _, ok := elem.(ir.Instruction); !ok {
+		return nil, false
+	}
+	v, ok := elem.(value.Value)
+	if !ok {
+		return nil, false
+	}
+	if v.Type().Equal(types.Void) {
+		return nil, false
+	}
+	return v, true
+}
+
+// uses returns the operands read by elem.
+func uses(elem interface{}) []value.Value {
+	switch e := elem.(type) {
+	case *ir.InstAdd:
+		return []value.Value{e.X, e.Y}
+	case *ir.InstLoad:
+		return []value.Value{e.Src}
+	case *ir.InstStore:
+		return []value.Value{e.Src, e.Dst}
+	case *ir.InstGetElementPtr:
+		return append([]value.Value{e.Src}, e.Indices...)
+	case *ir.InstCall:
+		return append([]value.Value{e.Callee}, e.Args...)
+	case *ir.InstPhi:
+		vs := make([]value.Value, 0, len(e.Incs))
+		for _, inc := range e.Incs {
+			vs = append(vs, inc.X)
+		}
+		return vs
+	case *ir.TermRet:
+		if e.X != nil {
+			return []value.Value{e.X}
+		}
+		return nil
+	case *ir.TermCondBr:
+		return []value.Value{e.Cond}
+	}
+	return nil
+}
+
+// exprKey canonicalizes the pure, side-effect-free instructions (add,
+// getelementptr) as expressions; load, store, call and phi are excluded, as
+// their availability additionally depends on memory state or control flow.
+func exprKey(inst ir.Instruction) (ExprKey, bool) {
+	switch inst := inst.(type) {
+	case *ir.InstAdd:
+		return ExprKey(fmt.Sprintf("add %s, %s", inst.X.Ident(), inst.Y.Ident())), true
+	case *ir.InstGetElementPtr:
+		buf := &strings.Builder{}
+		fmt.Fprintf(buf, "getelementptr %s, %s", inst.ElemType, inst.Src.Ident())
+		for _, idx := range inst.Indices {
+			fmt.Fprintf(buf, ", %s", idx.Ident())
+		}
+		return ExprKey(buf.String()), true
+	default:
+		return "", false
+	}
+}
+
+// allExprs returns the set of expressions (as computed by exprKey) appearing
+// anywhere in f; used to seed the top element of must-analyses.
+func allExprs(f *ir.Function) ExprSet {
+	exprs := make(ExprSet)
+	for _, b := range f.Blocks {
+		for _, inst := range b.Insts {
+			if k, ok := exprKey(inst); ok {
+				exprs[k] = true
+			}
+		}
+	}
+	return exprs
+}
+
+// unionMeet is the Meet operator for may-analyses.
+func unionMeet(a, b Set) Set {
+	return a.Union(b)
+}
+
+// intersectMeet is the Meet operator for must-analyses.
+func intersectMeet(a, b Set) Set {
+	return a.Intersect(b)
+}
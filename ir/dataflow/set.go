@@ -0,0 +1,136 @@
+package dataflow
+
+import (
+	"github.com/llir/llvm/ir/value"
+)
+
+// === [ Sets ] =================================================================
+
+// ValueSet is a Set of SSA values, the lattice element of value-based
+// analyses (live variables, reaching definitions).
+type ValueSet map[value.Value]bool
+
+// Union returns the union of s and other.
+func (s ValueSet) Union(other Set) Set {
+	o := other.(ValueSet)
+	res := make(ValueSet, len(s)+len(o))
+	for v := range s {
+		res[v] = true
+	}
+	for v := range o {
+		res[v] = true
+	}
+	return res
+}
+
+// Intersect returns the intersection of s and other.
+func (s ValueSet) Intersect(other Set) Set {
+	o := other.(ValueSet)
+	res := make(ValueSet)
+	for v := range s {
+		if o[v] {
+			res[v] = true
+		}
+	}
+	return res
+}
+
+// Equal reports whether s and other contain the same values.
+func (s ValueSet) Equal(other Set) bool {
+	o := other.(ValueSet)
+	if len(s) != len(o) {
+		return false
+	}
+	for v := range s {
+		if !o[v] {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of s.
+func (s ValueSet) Clone() Set {
+	res := make(ValueSet, len(s))
+	for v := range s {
+		res[v] = true
+	}
+	return res
+}
+
+// Add returns a copy of s with v added.
+func (s ValueSet) Add(v value.Value) ValueSet {
+	res := s.Clone().(ValueSet)
+	res[v] = true
+	return res
+}
+
+// Remove returns a copy of s with v removed.
+func (s ValueSet) Remove(v value.Value) ValueSet {
+	res := s.Clone().(ValueSet)
+	delete(res, v)
+	return res
+}
+
+// ExprKey canonicalizes an instruction for use as an expression identity: its
+// opcode together with the identifiers of its operands.
+type ExprKey string
+
+// ExprSet is a Set of canonicalized expressions, the lattice element of
+// expression-based analyses (available expressions, very busy expressions).
+type ExprSet map[ExprKey]bool
+
+// Union returns the union of s and other.
+func (s ExprSet) Union(other Set) Set {
+	o := other.(ExprSet)
+	res := make(ExprSet, len(s)+len(o))
+	for k := range s {
+		res[k] = true
+	}
+	for k := range o {
+		res[k] = true
+	}
+	return res
+}
+
+// Intersect returns the intersection of s and other.
+func (s ExprSet) Intersect(other Set) Set {
+	o := other.(ExprSet)
+	res := make(ExprSet)
+	for k := range s {
+		if o[k] {
+			res[k] = true
+		}
+	}
+	return res
+}
+
+// Equal reports whether s and other contain the same expressions.
+func (s ExprSet) Equal(other Set) bool {
+	o := other.(ExprSet)
+	if len(s) != len(o) {
+		return false
+	}
+	for k := range s {
+		if !o[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a copy of s.
+func (s ExprSet) Clone() Set {
+	res := make(ExprSet, len(s))
+	for k := range s {
+		res[k] = true
+	}
+	return res
+}
+
+// Add returns a copy of s with k added.
+func (s ExprSet) Add(k ExprKey) ExprSet {
+	res := s.Clone().(ExprSet)
+	res[k] = true
+	return res
+}
@@ -0,0 +1,103 @@
+// Package dom computes the dominator tree and dominance frontier of the
+// control flow graph of an *ir.Function.
+package dom
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// === [ Dominator trees ] =====================================================
+
+// DomTree represents the dominator (or post-dominator) tree of the control
+// flow graph of a function.
+type DomTree struct {
+	// idom maps a basic block to its immediate dominator; the entry block (or
+	// the virtual exit node of a post-dominator tree) has no entry.
+	idom map[*ir.BasicBlock]*ir.BasicBlock
+	// children maps a basic block to the set of blocks it immediately
+	// dominates.
+	children map[*ir.BasicBlock][]*ir.BasicBlock
+	// frontier maps a basic block to its dominance frontier.
+	frontier map[*ir.BasicBlock][]*ir.BasicBlock
+}
+
+// Build computes the dominator tree of the control flow graph of f, derived
+// from f.Blocks and the successors of each block's terminator.
+func Build(f *ir.Function) *DomTree {
+	if len(f.Blocks) == 0 {
+		return &DomTree{}
+	}
+	preds := computePreds(f.Blocks, f.Successors)
+	predFn := func(b *ir.BasicBlock) []*ir.BasicBlock {
+		return preds[b]
+	}
+	return build(f.Blocks[0], f.Successors, predFn)
+}
+
+// BuildPostDomTree computes the post-dominator tree of the control flow graph
+// of f: the dominator tree of the CFG with edges reversed, rooted at a
+// synthesized virtual exit node with an edge from every block that has no
+// successors.
+func BuildPostDomTree(f *ir.Function) *DomTree {
+	if len(f.Blocks) == 0 {
+		return &DomTree{}
+	}
+	preds := computePreds(f.Blocks, f.Successors)
+	var exits []*ir.BasicBlock
+	isExit := make(map[*ir.BasicBlock]bool)
+	for _, b := range f.Blocks {
+		if len(f.Successors(b)) == 0 {
+			exits = append(exits, b)
+			isExit[b] = true
+		}
+	}
+	// Virtual exit node; not a member of f.Blocks.
+	root := &ir.BasicBlock{}
+	// succFn and predFn operate on the CFG with edges reversed, with an added
+	// edge from root to each exit block.
+	succFn := func(b *ir.BasicBlock) []*ir.BasicBlock {
+		if b == root {
+			return exits
+		}
+		return preds[b]
+	}
+	predFn := func(b *ir.BasicBlock) []*ir.BasicBlock {
+		if b == root {
+			return nil
+		}
+		ps := f.Successors(b)
+		if isExit[b] {
+			ps = append(ps, root)
+		}
+		return ps
+	}
+	return build(root, succFn, predFn)
+}
+
+// IDom returns the immediate dominator of b, or nil if b is the root of the
+// tree.
+func (t *DomTree) IDom(b *ir.BasicBlock) *ir.BasicBlock {
+	return t.idom[b]
+}
+
+// Dominates reports whether a dominates b (a block is considered to dominate
+// itself).
+func (t *DomTree) Dominates(a, b *ir.BasicBlock) bool {
+	for n := b; n != nil; n = t.idom[n] {
+		if n == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Children returns the basic blocks immediately dominated by b.
+func (t *DomTree) Children(b *ir.BasicBlock) []*ir.BasicBlock {
+	return t.children[b]
+}
+
+// Frontier returns the dominance frontier of b: the set of blocks that b does
+// not strictly dominate but whose predecessors it does.
+func (t *DomTree) Frontier(b *ir.BasicBlock) []*ir.BasicBlock {
+	return t.frontier[b]
+}
@@ -0,0 +1,186 @@
+package dom
+
+import (
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/types"
+)
+
+// newTestFunc returns an empty function with a single i1 parameter named
+// "cond", suitable as a branch condition in hand-built control flow graphs.
+func newTestFunc(name string) (*ir.Function, *ir.Param) {
+	cond := ir.NewParam("cond", types.I1)
+	f := ir.NewFunction(name, types.Void, cond)
+	return f, cond
+}
+
+func containsBlock(bs []*ir.BasicBlock, b *ir.BasicBlock) bool {
+	for _, x := range bs {
+		if x == b {
+			return true
+		}
+	}
+	return false
+}
+
+// TestBuildDiamond builds:
+//
+//	entry
+//	/    \
+//
+// then    else
+//
+//	\    /
+//	  end
+func TestBuildDiamond(t *testing.T) {
+	f, cond := newTestFunc("diamond")
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewBr(end)
+	elseB.NewBr(end)
+	end.NewRet(nil)
+
+	tree := Build(f)
+
+	for _, b := range []*ir.BasicBlock{thenB, elseB, end} {
+		if got := tree.IDom(b); got != entry {
+			t.Errorf("IDom(%s) = %v, want entry", b.Ident(), got)
+		}
+	}
+	if got := tree.IDom(entry); got != nil {
+		t.Errorf("IDom(entry) = %v, want nil (root)", got)
+	}
+
+	children := tree.Children(entry)
+	for _, b := range []*ir.BasicBlock{thenB, elseB, end} {
+		if !containsBlock(children, b) {
+			t.Errorf("Children(entry) = %v, want it to include %s", children, b.Ident())
+		}
+	}
+
+	for _, b := range []*ir.BasicBlock{thenB, elseB} {
+		frontier := tree.Frontier(b)
+		if len(frontier) != 1 || frontier[0] != end {
+			t.Errorf("Frontier(%s) = %v, want [end]", b.Ident(), frontier)
+		}
+	}
+	if frontier := tree.Frontier(end); len(frontier) != 0 {
+		t.Errorf("Frontier(end) = %v, want empty", frontier)
+	}
+
+	if !tree.Dominates(entry, end) {
+		t.Error("Dominates(entry, end) = false, want true")
+	}
+	if tree.Dominates(thenB, elseB) {
+		t.Error("Dominates(then, else) = true, want false")
+	}
+}
+
+// TestBuildLoop builds:
+//
+//	entry -> header <-> body
+//	           |
+//	          exit
+func TestBuildLoop(t *testing.T) {
+	f, cond := newTestFunc("loop")
+	entry := f.NewBlock("entry")
+	header := f.NewBlock("header")
+	body := f.NewBlock("body")
+	exit := f.NewBlock("exit")
+	entry.NewBr(header)
+	header.NewCondBr(cond, body, exit)
+	body.NewBr(header)
+	exit.NewRet(nil)
+
+	tree := Build(f)
+
+	if got := tree.IDom(header); got != entry {
+		t.Errorf("IDom(header) = %v, want entry", got)
+	}
+	if got := tree.IDom(body); got != header {
+		t.Errorf("IDom(body) = %v, want header", got)
+	}
+	if got := tree.IDom(exit); got != header {
+		t.Errorf("IDom(exit) = %v, want header", got)
+	}
+
+	// The back edge body->header makes header a join point dominated by
+	// itself through body, so header lies in its own dominance frontier.
+	if frontier := tree.Frontier(header); len(frontier) != 1 || frontier[0] != header {
+		t.Errorf("Frontier(header) = %v, want [header]", frontier)
+	}
+	if frontier := tree.Frontier(body); len(frontier) != 1 || frontier[0] != header {
+		t.Errorf("Frontier(body) = %v, want [header]", frontier)
+	}
+
+	if !tree.Dominates(header, body) {
+		t.Error("Dominates(header, body) = false, want true")
+	}
+	if tree.Dominates(body, header) {
+		t.Error("Dominates(body, header) = true, want false")
+	}
+}
+
+// TestBuildIrreducible builds the classic irreducible CFG where two blocks
+// forming a cycle (A, B) are each reachable directly from entry, so neither
+// can be the cycle's sole header:
+//
+//	entry -> A, B
+//	A -> B, exit
+//	B -> A, exit
+func TestBuildIrreducible(t *testing.T) {
+	f, cond := newTestFunc("irreducible")
+	entry := f.NewBlock("entry")
+	a := f.NewBlock("a")
+	b := f.NewBlock("b")
+	exit := f.NewBlock("exit")
+	entry.NewCondBr(cond, a, b)
+	a.NewCondBr(cond, b, exit)
+	b.NewCondBr(cond, a, exit)
+	exit.NewRet(nil)
+
+	tree := Build(f)
+
+	for _, blk := range []*ir.BasicBlock{a, b, exit} {
+		if got := tree.IDom(blk); got != entry {
+			t.Errorf("IDom(%s) = %v, want entry", blk.Ident(), got)
+		}
+	}
+	if tree.Dominates(a, b) {
+		t.Error("Dominates(a, b) = true, want false (reachable from entry without passing through a)")
+	}
+	if tree.Dominates(b, a) {
+		t.Error("Dominates(b, a) = true, want false (reachable from entry without passing through b)")
+	}
+	if tree.Dominates(a, exit) {
+		t.Error("Dominates(a, exit) = true, want false (reachable via b without passing through a)")
+	}
+	if !tree.Dominates(entry, exit) {
+		t.Error("Dominates(entry, exit) = false, want true")
+	}
+}
+
+// TestBuildPostDomTreeDiamond checks that every block of a diamond is
+// post-dominated by its merge point.
+func TestBuildPostDomTreeDiamond(t *testing.T) {
+	f, cond := newTestFunc("diamond")
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewBr(end)
+	elseB.NewBr(end)
+	end.NewRet(nil)
+
+	tree := BuildPostDomTree(f)
+	for _, blk := range []*ir.BasicBlock{entry, thenB, elseB} {
+		if !tree.Dominates(end, blk) {
+			t.Errorf("post-dom Dominates(end, %s) = false, want true", blk.Ident())
+		}
+	}
+}
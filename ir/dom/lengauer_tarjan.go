@@ -0,0 +1,174 @@
+package dom
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// build computes the dominator tree of the graph reachable from root through
+// succ, using the Lengauer-Tarjan algorithm, and its dominance frontier using
+// Cytron's algorithm.
+func build(root *ir.BasicBlock, succ, pred func(*ir.BasicBlock) []*ir.BasicBlock) *DomTree {
+	// 1. DFS from root, numbering blocks in preorder.
+	vertex, parent := dfs(root, succ)
+	dfn := make(map[*ir.BasicBlock]int, len(vertex))
+	for i, b := range vertex {
+		dfn[b] = i
+	}
+	n := len(vertex)
+
+	semi := make([]int, n)
+	idomn := make([]int, n)
+	ancestor := make([]int, n)
+	label := make([]int, n)
+	bucket := make([][]int, n)
+	for i := range vertex {
+		semi[i] = i
+		label[i] = i
+		ancestor[i] = -1
+	}
+
+	// compress collapses the path from v to the root of its ancestor tree,
+	// updating label[v] to the ancestor with minimal semi along the way.
+	var compress func(v int)
+	compress = func(v int) {
+		if ancestor[ancestor[v]] != -1 {
+			compress(ancestor[v])
+			if semi[label[ancestor[v]]] < semi[label[v]] {
+				label[v] = label[ancestor[v]]
+			}
+			ancestor[v] = ancestor[ancestor[v]]
+		}
+	}
+	// eval returns the vertex with minimal semi on the path from v to the
+	// root of its ancestor tree.
+	eval := func(v int) int {
+		if ancestor[v] == -1 {
+			return v
+		}
+		compress(v)
+		return label[v]
+	}
+	link := func(v, w int) {
+		ancestor[w] = v
+	}
+	parentOf := func(i int) int {
+		p, ok := parent[vertex[i]]
+		if !ok {
+			return -1
+		}
+		return dfn[p]
+	}
+
+	// 2. Process vertices in reverse preorder, computing semidominators and
+	// deferring idom computation via buckets.
+	for i := n - 1; i >= 1; i-- {
+		w := i
+		for _, vBlock := range pred(vertex[w]) {
+			v, ok := dfn[vBlock]
+			if !ok {
+				// Unreachable predecessor; ignore.
+				continue
+			}
+			u := eval(v)
+			if semi[u] < semi[w] {
+				semi[w] = semi[u]
+			}
+		}
+		bucket[semi[w]] = append(bucket[semi[w]], w)
+		pw := parentOf(w)
+		link(pw, w)
+		for _, v := range bucket[pw] {
+			u := eval(v)
+			if semi[u] < semi[v] {
+				idomn[v] = u
+			} else {
+				idomn[v] = pw
+			}
+		}
+		bucket[pw] = nil
+	}
+
+	// 3. Forward pass fixing idom where it was set to something other than
+	// the semidominator.
+	for i := 1; i < n; i++ {
+		if idomn[i] != semi[i] {
+			idomn[i] = idomn[idomn[i]]
+		}
+	}
+
+	idom := make(map[*ir.BasicBlock]*ir.BasicBlock, n)
+	children := make(map[*ir.BasicBlock][]*ir.BasicBlock, n)
+	for i := 1; i < n; i++ {
+		b, d := vertex[i], vertex[idomn[i]]
+		idom[b] = d
+		children[d] = append(children[d], b)
+	}
+
+	frontier := dominanceFrontier(vertex, dfn, idom, pred)
+
+	return &DomTree{idom: idom, children: children, frontier: frontier}
+}
+
+// computePreds returns a mapping from each basic block reachable through succ
+// to its set of predecessors.
+func computePreds(blocks []*ir.BasicBlock, succ func(*ir.BasicBlock) []*ir.BasicBlock) map[*ir.BasicBlock][]*ir.BasicBlock {
+	preds := make(map[*ir.BasicBlock][]*ir.BasicBlock, len(blocks))
+	for _, b := range blocks {
+		for _, s := range succ(b) {
+			preds[s] = append(preds[s], b)
+		}
+	}
+	return preds
+}
+
+// dfs performs a depth-first search from root through succ, returning the
+// blocks in preorder and a mapping from each (non-root) block to its DFS tree
+// parent.
+func dfs(root *ir.BasicBlock, succ func(*ir.BasicBlock) []*ir.BasicBlock) ([]*ir.BasicBlock, map[*ir.BasicBlock]*ir.BasicBlock) {
+	var vertex []*ir.BasicBlock
+	visited := make(map[*ir.BasicBlock]bool)
+	parent := make(map[*ir.BasicBlock]*ir.BasicBlock)
+	var visit func(b *ir.BasicBlock)
+	visit = func(b *ir.BasicBlock) {
+		visited[b] = true
+		vertex = append(vertex, b)
+		for _, w := range succ(b) {
+			if !visited[w] {
+				parent[w] = b
+				visit(w)
+			}
+		}
+	}
+	visit(root)
+	return vertex, parent
+}
+
+// dominanceFrontier computes the dominance frontier of every block using
+// Cytron's algorithm: for each join point b (a block with at least two
+// predecessors), walk up the dominator tree from each predecessor until
+// reaching b's immediate dominator, adding b to the frontier of every block
+// visited along the way.
+func dominanceFrontier(vertex []*ir.BasicBlock, dfn map[*ir.BasicBlock]int, idom map[*ir.BasicBlock]*ir.BasicBlock, pred func(*ir.BasicBlock) []*ir.BasicBlock) map[*ir.BasicBlock][]*ir.BasicBlock {
+	frontier := make(map[*ir.BasicBlock][]*ir.BasicBlock)
+	for _, b := range vertex {
+		ps := pred(b)
+		if len(ps) < 2 {
+			continue
+		}
+		idomB := idom[b]
+		seen := make(map[*ir.BasicBlock]bool)
+		for _, p := range ps {
+			if _, ok := dfn[p]; !ok {
+				continue
+			}
+			for runner := p; runner != nil && runner != idomB; runner = idom[runner] {
+				if seen[runner] {
+					break
+				}
+				seen[runner] = true
+				frontier[runner] = append(frontier[runner], b)
+			}
+		}
+	}
+	return frontier
+}
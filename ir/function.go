@@ -73,15 +73,12 @@ type Function struct {
 // NewFunction returns a new function based on the given function name, return
 // type and function parameters.
 func NewFunction(name string, retType types.Type, params ...*Param) *Function {
-	panic("not yet implemented")
-	/*
-		paramTypes := make([]types.Type, len(params))
-		for i, param := range f.Params {
-			paramType[i] = param.Type()
-		}
-		sig := types.NewFunc(f.RetType, paramTypes...)
-		return &Function{Sig: sig, GlobalName: name, Params: params}
-	*/
+	paramTypes := make([]types.Type, len(params))
+	for i, param := range params {
+		paramTypes[i] = param.Type()
+	}
+	sig := types.NewFunc(retType, paramTypes...)
+	return &Function{Sig: sig, GlobalName: name, Params: params}
 }
 
 // String returns the LLVM syntax representation of the function as a type-value
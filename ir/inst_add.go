@@ -0,0 +1,59 @@
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// InstAdd is an LLVM IR add instruction.
+type InstAdd struct {
+	// Name of local variable associated with the result.
+	LocalIdent
+	// Operands.
+	X, Y value.Value
+
+	// extra.
+
+	// Type of result produced by the instruction.
+	Typ types.Type
+}
+
+// NewAdd returns a new add instruction based on the given operands.
+func NewAdd(x, y value.Value) *InstAdd {
+	return &InstAdd{X: x, Y: y}
+}
+
+// String returns the LLVM syntax representation of the instruction as a
+// type-value pair.
+func (inst *InstAdd) String() string {
+	return fmt.Sprintf("%s %s", inst.Type(), inst.Ident())
+}
+
+// Type returns the type of the instruction.
+func (inst *InstAdd) Type() types.Type {
+	// Cache type if not present; result type matches the type of the first
+	// operand.
+	if inst.Typ == nil {
+		inst.Typ = inst.X.Type()
+	}
+	return inst.Typ
+}
+
+// Def returns the LLVM syntax representation of the instruction definition.
+func (inst *InstAdd) Def() string {
+	return fmt.Sprintf("%s = add %s %s, %s", inst.Ident(), inst.Type(), inst.X.Ident(), inst.Y.Ident())
+}
+
+// isInst ensures that only instructions can be assigned to the Instruction
+// interface.
+func (*InstAdd) isInst() {}
+
+// NewAdd appends a new add instruction to the basic block based on the given
+// operands and returns it.
+func (block *BasicBlock) NewAdd(x, y value.Value) *InstAdd {
+	inst := NewAdd(x, y)
+	block.Insts = append(block.Insts, inst)
+	return inst
+}
@@ -0,0 +1,64 @@
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// InstAlloca is an LLVM IR alloca instruction.
+type InstAlloca struct {
+	// Name of local variable associated with the result.
+	LocalIdent
+	// Element type of the allocated memory.
+	ElemType types.Type
+	// (optional) Number of elements allocated; nil if a single element is
+	// allocated.
+	NElems value.Value
+
+	// extra.
+
+	// Type of result produced by the instruction.
+	Typ *types.PointerType
+}
+
+// NewAlloca returns a new alloca instruction based on the given element type.
+func NewAlloca(elemType types.Type) *InstAlloca {
+	return &InstAlloca{ElemType: elemType}
+}
+
+// String returns the LLVM syntax representation of the instruction as a
+// type-value pair.
+func (inst *InstAlloca) String() string {
+	return fmt.Sprintf("%s %s", inst.Type(), inst.Ident())
+}
+
+// Type returns the type of the instruction.
+func (inst *InstAlloca) Type() types.Type {
+	// Cache type if not present.
+	if inst.Typ == nil {
+		inst.Typ = types.NewPointer(inst.ElemType)
+	}
+	return inst.Typ
+}
+
+// Def returns the LLVM syntax representation of the instruction definition.
+func (inst *InstAlloca) Def() string {
+	if inst.NElems != nil {
+		return fmt.Sprintf("%s = alloca %s, %s %s", inst.Ident(), inst.ElemType, inst.NElems.Type(), inst.NElems.Ident())
+	}
+	return fmt.Sprintf("%s = alloca %s", inst.Ident(), inst.ElemType)
+}
+
+// isInst ensures that only instructions can be assigned to the Instruction
+// interface.
+func (*InstAlloca) isInst() {}
+
+// NewAlloca appends a new alloca instruction to the basic block based on the
+// given element type and returns it.
+func (block *BasicBlock) NewAlloca(elemType types.Type) *InstAlloca {
+	inst := NewAlloca(elemType)
+	block.Insts = append(block.Insts, inst)
+	return inst
+}
@@ -0,0 +1,89 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// InstCall is an LLVM IR call instruction.
+type InstCall struct {
+	// Name of local variable associated with the result, if any.
+	LocalIdent
+	// Callee.
+	Callee value.Value
+	// Function arguments.
+	Args []value.Value
+
+	// extra.
+
+	// Type of result produced by the instruction.
+	Typ types.Type
+}
+
+// NewCall returns a new call instruction based on the given callee and
+// function arguments.
+func NewCall(callee value.Value, args ...value.Value) *InstCall {
+	return &InstCall{Callee: callee, Args: args}
+}
+
+// String returns the LLVM syntax representation of the instruction as a
+// type-value pair.
+func (inst *InstCall) String() string {
+	return fmt.Sprintf("%s %s", inst.Type(), inst.Ident())
+}
+
+// Type returns the type of the instruction.
+func (inst *InstCall) Type() types.Type {
+	// Cache type if not present; result type matches the return type of the
+	// callee signature.
+	if inst.Typ == nil {
+		inst.Typ = calleeSig(inst.Callee).RetType
+	}
+	return inst.Typ
+}
+
+// Def returns the LLVM syntax representation of the instruction definition.
+func (inst *InstCall) Def() string {
+	buf := &strings.Builder{}
+	if !inst.Type().Equal(types.Void) {
+		fmt.Fprintf(buf, "%s = ", inst.Ident())
+	}
+	fmt.Fprintf(buf, "call %s %s(", inst.Type(), inst.Callee.Ident())
+	for i, arg := range inst.Args {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "%s %s", arg.Type(), arg.Ident())
+	}
+	buf.WriteString(")")
+	return buf.String()
+}
+
+// isInst ensures that only instructions can be assigned to the Instruction
+// interface.
+func (*InstCall) isInst() {}
+
+// NewCall appends a new call instruction to the basic block based on the
+// given callee and function arguments and returns it.
+func (block *BasicBlock) NewCall(callee value.Value, args ...value.Value) *InstCall {
+	inst := NewCall(callee, args...)
+	block.Insts = append(block.Insts, inst)
+	return inst
+}
+
+// calleeSig returns the function signature of the given callee, unwrapping a
+// pointer to function type if necessary.
+func calleeSig(callee value.Value) *types.FuncType {
+	switch t := callee.Type().(type) {
+	case *types.FuncType:
+		return t
+	case *types.PointerType:
+		if sig, ok := t.ElemType.(*types.FuncType); ok {
+			return sig
+		}
+	}
+	panic(fmt.Errorf("invalid callee type; expected function or pointer to function, got %T", callee.Type()))
+}
@@ -0,0 +1,98 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// InstGetElementPtr is an LLVM IR getelementptr instruction.
+type InstGetElementPtr struct {
+	// Name of local variable associated with the result.
+	LocalIdent
+	// Element type of the base pointer.
+	ElemType types.Type
+	// Source address.
+	Src value.Value
+	// Element indices.
+	Indices []value.Value
+
+	// extra.
+
+	// Type of result produced by the instruction.
+	Typ *types.PointerType
+}
+
+// NewGetElementPtr returns a new getelementptr instruction based on the given
+// element type, source address and element indices.
+func NewGetElementPtr(elemType types.Type, src value.Value, indices ...value.Value) *InstGetElementPtr {
+	return &InstGetElementPtr{ElemType: elemType, Src: src, Indices: indices}
+}
+
+// String returns the LLVM syntax representation of the instruction as a
+// type-value pair.
+func (inst *InstGetElementPtr) String() string {
+	return fmt.Sprintf("%s %s", inst.Type(), inst.Ident())
+}
+
+// Type returns the type of the instruction.
+func (inst *InstGetElementPtr) Type() types.Type {
+	// Cache type if not present.
+	if inst.Typ == nil {
+		elemType := gepElemType(inst.ElemType, inst.Indices)
+		inst.Typ = types.NewPointer(elemType)
+	}
+	return inst.Typ
+}
+
+// Def returns the LLVM syntax representation of the instruction definition.
+func (inst *InstGetElementPtr) Def() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%s = getelementptr %s, %s %s", inst.Ident(), inst.ElemType, inst.Src.Type(), inst.Src.Ident())
+	for _, index := range inst.Indices {
+		fmt.Fprintf(buf, ", %s %s", index.Type(), index.Ident())
+	}
+	return buf.String()
+}
+
+// isInst ensures that only instructions can be assigned to the Instruction
+// interface.
+func (*InstGetElementPtr) isInst() {}
+
+// NewGetElementPtr appends a new getelementptr instruction to the basic block
+// based on the given element type, source address and element indices and
+// returns it.
+func (block *BasicBlock) NewGetElementPtr(elemType types.Type, src value.Value, indices ...value.Value) *InstGetElementPtr {
+	inst := NewGetElementPtr(elemType, src, indices...)
+	block.Insts = append(block.Insts, inst)
+	return inst
+}
+
+// gepElemType walks the given indices (skipping the first, which merely
+// indexes through the base pointer) to compute the element type addressed by
+// a getelementptr instruction.
+func gepElemType(elemType types.Type, indices []value.Value) types.Type {
+	if len(indices) == 0 {
+		return elemType
+	}
+	for _, index := range indices[1:] {
+		switch t := elemType.(type) {
+		case *types.ArrayType:
+			elemType = t.ElemType
+		case *types.VectorType:
+			elemType = t.ElemType
+		case *types.StructType:
+			idx, ok := index.(*constant.Int)
+			if !ok {
+				panic(fmt.Errorf("invalid index type for struct field selection; expected *constant.Int, got %T", index))
+			}
+			elemType = t.Fields[idx.X.Int64()]
+		default:
+			panic(fmt.Errorf("support for indexing through element type %T not yet implemented", elemType))
+		}
+	}
+	return elemType
+}
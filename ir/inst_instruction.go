@@ -0,0 +1,29 @@
+package ir
+
+// === [ Instructions ] ========================================================
+
+// Instruction is an LLVM IR instruction. Instructions with a result (e.g.
+// add, load) additionally implement the value.Value interface and may thus
+// be used as values; instructions without a result (e.g. store, fence) do
+// not.
+type Instruction interface {
+	// Def returns the LLVM syntax representation of the instruction
+	// definition.
+	Def() string
+	// isInst ensures that only instructions can be assigned to the
+	// Instruction interface.
+	isInst()
+}
+
+// Terminator is an LLVM IR terminator instruction (a control flow instruction
+// that terminates a basic block, e.g. br, ret, switch).
+type Terminator interface {
+	// String returns the LLVM syntax representation of the terminator.
+	String() string
+	// Def returns the LLVM syntax representation of the terminator
+	// definition.
+	Def() string
+	// isTerm ensures that only terminators can be assigned to the Terminator
+	// interface.
+	isTerm()
+}
@@ -0,0 +1,52 @@
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// InstLoad is an LLVM IR load instruction.
+type InstLoad struct {
+	// Name of local variable associated with the result.
+	LocalIdent
+	// Type of the loaded value.
+	ElemType types.Type
+	// Source address.
+	Src value.Value
+}
+
+// NewLoad returns a new load instruction based on the given element type and
+// source address.
+func NewLoad(elemType types.Type, src value.Value) *InstLoad {
+	return &InstLoad{ElemType: elemType, Src: src}
+}
+
+// String returns the LLVM syntax representation of the instruction as a
+// type-value pair.
+func (inst *InstLoad) String() string {
+	return fmt.Sprintf("%s %s", inst.Type(), inst.Ident())
+}
+
+// Type returns the type of the instruction.
+func (inst *InstLoad) Type() types.Type {
+	return inst.ElemType
+}
+
+// Def returns the LLVM syntax representation of the instruction definition.
+func (inst *InstLoad) Def() string {
+	return fmt.Sprintf("%s = load %s, %s %s", inst.Ident(), inst.ElemType, inst.Src.Type(), inst.Src.Ident())
+}
+
+// isInst ensures that only instructions can be assigned to the Instruction
+// interface.
+func (*InstLoad) isInst() {}
+
+// NewLoad appends a new load instruction to the basic block based on the given
+// element type and source address and returns it.
+func (block *BasicBlock) NewLoad(elemType types.Type, src value.Value) *InstLoad {
+	inst := NewLoad(elemType, src)
+	block.Insts = append(block.Insts, inst)
+	return inst
+}
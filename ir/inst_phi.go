@@ -0,0 +1,86 @@
+package ir
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/llir/llvm/ir/types"
+	"github.com/llir/llvm/ir/value"
+)
+
+// Incoming represents an incoming value from a predecessor basic block, as
+// specified by phi instructions.
+type Incoming struct {
+	// Incoming value.
+	X value.Value
+	// Predecessor basic block of the incoming value.
+	Pred *BasicBlock
+}
+
+// NewIncoming returns a new incoming value based on the given value and
+// predecessor basic block.
+func NewIncoming(x value.Value, pred *BasicBlock) *Incoming {
+	return &Incoming{X: x, Pred: pred}
+}
+
+// InstPhi is an LLVM IR phi instruction.
+type InstPhi struct {
+	// Name of local variable associated with the result.
+	LocalIdent
+	// Incoming values.
+	Incs []*Incoming
+
+	// extra.
+
+	// Type of result produced by the instruction.
+	Typ types.Type
+}
+
+// NewPhi returns a new phi instruction based on the given incoming values.
+func NewPhi(incs ...*Incoming) *InstPhi {
+	return &InstPhi{Incs: incs}
+}
+
+// String returns the LLVM syntax representation of the instruction as a
+// type-value pair.
+func (inst *InstPhi) String() string {
+	return fmt.Sprintf("%s %s", inst.Type(), inst.Ident())
+}
+
+// Type returns the type of the instruction.
+func (inst *InstPhi) Type() types.Type {
+	// Cache type if not present; result type matches the type of the incoming
+	// values, which must agree.
+	if inst.Typ == nil {
+		if len(inst.Incs) == 0 {
+			panic("unable to infer type of phi instruction with no incoming values")
+		}
+		inst.Typ = inst.Incs[0].X.Type()
+	}
+	return inst.Typ
+}
+
+// Def returns the LLVM syntax representation of the instruction definition.
+func (inst *InstPhi) Def() string {
+	buf := &strings.Builder{}
+	fmt.Fprintf(buf, "%s = phi %s ", inst.Ident(), inst.Type())
+	for i, inc := range inst.Incs {
+		if i != 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "[ %s, %s ]", inc.X.Ident(), inc.Pred.Ident())
+	}
+	return buf.String()
+}
+
+// isInst ensures that only instructions can be assigned to the Instruction
+// interface.
+func (*InstPhi) isInst() {}
+
+// NewPhi appends a new phi instruction to the basic block based on the given
+// incoming values and returns it.
+func (block *BasicBlock) NewPhi(incs ...*Incoming) *InstPhi {
+	inst := NewPhi(incs...)
+	block.Insts = append(block.Insts, inst)
+	return inst
+}
@@ -0,0 +1,39 @@
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/value"
+)
+
+// InstStore is an LLVM IR store instruction. It produces no result and is
+// thus never referenced as a value.
+type InstStore struct {
+	// Source value.
+	Src value.Value
+	// Destination address.
+	Dst value.Value
+}
+
+// NewStore returns a new store instruction based on the given source value
+// and destination address.
+func NewStore(src, dst value.Value) *InstStore {
+	return &InstStore{Src: src, Dst: dst}
+}
+
+// Def returns the LLVM syntax representation of the instruction definition.
+func (inst *InstStore) Def() string {
+	return fmt.Sprintf("store %s %s, %s %s", inst.Src.Type(), inst.Src.Ident(), inst.Dst.Type(), inst.Dst.Ident())
+}
+
+// isInst ensures that only instructions can be assigned to the Instruction
+// interface.
+func (*InstStore) isInst() {}
+
+// NewStore appends a new store instruction to the basic block based on the
+// given source value and destination address and returns it.
+func (block *BasicBlock) NewStore(src, dst value.Value) *InstStore {
+	inst := NewStore(src, dst)
+	block.Insts = append(block.Insts, inst)
+	return inst
+}
@@ -0,0 +1,268 @@
+package ptranal
+
+import (
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/value"
+)
+
+// loadConstraint models "q = load p", i.e. pts(q) ⊇ pts(*p).
+type loadConstraint struct {
+	p, q *node
+}
+
+// storeConstraint models "store v, p", i.e. pts(*p) ⊇ pts(v).
+type storeConstraint struct {
+	p, v *node
+}
+
+// callConstraint models a call through a pointer not yet resolved to a
+// single function; bindCall is (re-)applied as the callee's points-to set
+// grows.
+type callConstraint struct {
+	calleeNode *node
+	call       *ir.InstCall
+}
+
+// builder accumulates the constraint graph for a module and solves it to a
+// fixpoint.
+type builder struct {
+	locs  []*Location
+	nodes map[value.Value]*node
+	// mem maps a location to the node representing the contents stored at
+	// that location (used to resolve load/store constraints).
+	mem map[*Location]*node
+	// ret maps a function to the node representing the values it may return.
+	ret map[*ir.Function]*node
+
+	loads  []loadConstraint
+	stores []storeConstraint
+	calls  []callConstraint
+
+	boundCalls map[*ir.InstCall]map[*ir.Function]bool
+
+	// siteLoc maps an allocation site (global, function, alloca or
+	// malloc-like call) to the location created for it, so that a global's
+	// initializer can be related back to its own memory.
+	siteLoc map[value.Value]*Location
+
+	worklist []*node
+}
+
+func newBuilder() *builder {
+	return &builder{
+		nodes:      make(map[value.Value]*node),
+		mem:        make(map[*Location]*node),
+		ret:        make(map[*ir.Function]*node),
+		boundCalls: make(map[*ir.InstCall]map[*ir.Function]bool),
+		siteLoc:    make(map[value.Value]*Location),
+	}
+}
+
+func (b *builder) nodeFor(v value.Value) *node {
+	if n, ok := b.nodes[v]; ok {
+		return b.find(n)
+	}
+	n := newNode()
+	b.nodes[v] = n
+	return n
+}
+
+func (b *builder) memFor(l *Location) *node {
+	if n, ok := b.mem[l]; ok {
+		return b.find(n)
+	}
+	n := newNode()
+	b.mem[l] = n
+	return n
+}
+
+func (b *builder) retNodeFor(f *ir.Function) *node {
+	if n, ok := b.ret[f]; ok {
+		return b.find(n)
+	}
+	n := newNode()
+	b.ret[f] = n
+	return n
+}
+
+// newLocation creates a fresh abstract location for the given allocation
+// site.
+func (b *builder) newLocation(name string, site value.Value) *Location {
+	l := &Location{Name: name, Site: site}
+	b.locs = append(b.locs, l)
+	b.siteLoc[site] = l
+	return l
+}
+
+// addModule translates every alloca, global, function, malloc-like call and
+// pointer-manipulating instruction of m into constraints.
+func (b *builder) addModule(m *ir.Module) {
+	for _, g := range m.Globals {
+		l := b.newLocation(g.Name(), g)
+		b.addBase(g, l)
+	}
+	for _, f := range m.Funcs {
+		l := b.newLocation(f.Name(), f)
+		b.addBase(f, l)
+	}
+	for _, g := range m.Globals {
+		b.addGlobalInit(g)
+	}
+	for _, f := range m.Funcs {
+		b.addFunction(f)
+	}
+}
+
+// addGlobalInit seeds the memory of g's own location with the locations
+// referenced by its initializer (e.g. the address of another global or
+// function, or a function-pointer table), so that a load out of g is not
+// treated as pointing to nothing.
+func (b *builder) addGlobalInit(g *ir.Global) {
+	if g.Init == nil {
+		return
+	}
+	mem := b.memFor(b.siteLoc[g])
+	b.addConstRefs(g.Init, mem)
+}
+
+// addConstRefs walks init, unwrapping constant expressions (e.g. bitcasts)
+// and descending into aggregates, and for every address-valued leaf (a
+// function, global, alias or ifunc) propagates its points-to set into to.
+func (b *builder) addConstRefs(init constant.Constant, to *node) {
+	switch c := init.(type) {
+	case *ir.Function, *ir.Global, *ir.Alias, *ir.IFunc:
+		b.addCopyNode(b.nodeFor(init), to)
+	case constant.Expression:
+		b.addConstRefs(c.Simplify(), to)
+	case *constant.Array:
+		for _, elem := range c.Elems {
+			b.addConstRefs(elem, to)
+		}
+	case *constant.Struct:
+		for _, field := range c.Fields {
+			b.addConstRefs(field, to)
+		}
+	}
+}
+
+func (b *builder) addFunction(f *ir.Function) {
+	for _, block := range f.Blocks {
+		for _, inst := range block.Insts {
+			b.addInst(inst)
+		}
+		if term, ok := block.Term.(*ir.TermRet); ok && term.X != nil {
+			b.addCopyNode(b.nodeFor(term.X), b.retNodeFor(f))
+		}
+	}
+}
+
+func (b *builder) addInst(inst ir.Instruction) {
+	switch inst := inst.(type) {
+	case *ir.InstAlloca:
+		l := b.newLocation(inst.Ident(), inst)
+		b.addBase(inst, l)
+	case *ir.InstLoad:
+		b.addLoad(inst.Src, inst)
+	case *ir.InstStore:
+		b.addStore(inst.Dst, inst.Src)
+	case *ir.InstGetElementPtr:
+		// Field-insensitive: the result may point to anything the base
+		// pointer points to.
+		b.addCopy(inst.Src, inst)
+	case *ir.InstPhi:
+		for _, inc := range inst.Incs {
+			b.addCopy(inc.X, inst)
+		}
+	case *ir.InstCall:
+		if isMallocLike(inst) {
+			l := b.newLocation(inst.Ident(), inst)
+			b.addBase(inst, l)
+			return
+		}
+		b.addCall(inst)
+	}
+}
+
+// isMallocLike reports whether call invokes a well-known heap allocation
+// function.
+func isMallocLike(call *ir.InstCall) bool {
+	f, ok := call.Callee.(*ir.Function)
+	if !ok {
+		return false
+	}
+	switch f.Name() {
+	case "malloc", "calloc", "realloc":
+		return true
+	default:
+		return false
+	}
+}
+
+// addBase records a base constraint: pts(v) ⊇ {l}.
+func (b *builder) addBase(v value.Value, l *Location) {
+	n := b.nodeFor(v)
+	if !n.pts[l] {
+		n.pts[l] = true
+		b.worklist = append(b.worklist, n)
+	}
+}
+
+// addCopy records a simple (subset) constraint: pts(to) ⊇ pts(from).
+func (b *builder) addCopy(from, to value.Value) {
+	b.addCopyNode(b.nodeFor(from), b.nodeFor(to))
+}
+
+// addLoad records the complex constraint pts(q) ⊇ pts(*p).
+func (b *builder) addLoad(p, q value.Value) {
+	pn, qn := b.nodeFor(p), b.nodeFor(q)
+	b.loads = append(b.loads, loadConstraint{p: pn, q: qn})
+	for l := range pn.pts {
+		b.addCopyNode(b.memFor(l), qn)
+	}
+}
+
+// addStore records the complex constraint pts(*p) ⊇ pts(v).
+func (b *builder) addStore(p, v value.Value) {
+	pn, vn := b.nodeFor(p), b.nodeFor(v)
+	b.stores = append(b.stores, storeConstraint{p: pn, v: vn})
+	for l := range pn.pts {
+		b.addCopyNode(vn, b.memFor(l))
+	}
+}
+
+// addCall binds a call's actual arguments to the callee's formal parameters
+// and the callee's return value to the call's result. Direct calls are bound
+// immediately; indirect calls are bound against every function observed in
+// the callee operand's points-to set so far, and re-bound as that set grows
+// during solve.
+func (b *builder) addCall(call *ir.InstCall) {
+	if callee, ok := call.Callee.(*ir.Function); ok {
+		b.bindCall(callee, call)
+		return
+	}
+	calleeNode := b.nodeFor(call.Callee)
+	b.calls = append(b.calls, callConstraint{calleeNode: calleeNode, call: call})
+	for l := range calleeNode.pts {
+		if f, ok := l.Site.(*ir.Function); ok {
+			b.bindCall(f, call)
+		}
+	}
+}
+
+func (b *builder) bindCall(callee *ir.Function, call *ir.InstCall) {
+	if b.boundCalls[call] == nil {
+		b.boundCalls[call] = make(map[*ir.Function]bool)
+	}
+	if b.boundCalls[call][callee] {
+		return
+	}
+	b.boundCalls[call][callee] = true
+	for i, arg := range call.Args {
+		if i >= len(callee.Params) {
+			break // varargs are not modeled
+		}
+		b.addCopy(arg, callee.Params[i])
+	}
+	b.addCopyNode(b.retNodeFor(callee), b.nodeFor(call))
+}
@@ -0,0 +1,20 @@
+package ptranal
+
+// node is a constraint variable: a points-to set together with the set of
+// copy edges (subset constraints) along which it propagates.
+type node struct {
+	// pts is the current points-to set of the node.
+	pts map[*Location]bool
+	// succ holds the nodes that this node has a copy edge into, i.e. for
+	// every s in succ, pts(s) ⊇ pts(n).
+	succ map[*node]bool
+
+	// parent is set when this node has been merged into another as part of
+	// collapsing a cycle in the subset graph (see builder.find/merge); nil
+	// for a node that is its own representative.
+	parent *node
+}
+
+func newNode() *node {
+	return &node{pts: make(map[*Location]bool), succ: make(map[*node]bool)}
+}
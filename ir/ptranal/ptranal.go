@@ -0,0 +1,89 @@
+// Package ptranal implements a whole-module, inclusion-based ("Andersen")
+// points-to analysis over *ir.Module.
+package ptranal
+
+import (
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/value"
+)
+
+// === [ Points-to analysis ] ===================================================
+
+// Location is an abstract memory location: the target of a pointer, arising
+// from an alloca instruction, a global variable, a function (taken as a
+// function pointer) or a malloc-like call.
+type Location struct {
+	// Name of the location, for diagnostics.
+	Name string
+	// Site is the IR value the location was created for (an *ir.InstAlloca,
+	// *ir.Global, *ir.Function or *ir.InstCall).
+	Site value.Value
+}
+
+// Result is the result of points-to analysis over a module.
+type Result struct {
+	locs  []*Location
+	nodes map[value.Value]*node
+	mem   map[*Location]*node
+}
+
+// PointsTo returns the set of locations v may point to.
+func (r *Result) PointsTo(v value.Value) []Location {
+	n, ok := r.nodes[v]
+	if !ok {
+		return nil
+	}
+	n = find(n)
+	locs := make([]Location, 0, len(n.pts))
+	for l := range n.pts {
+		locs = append(locs, *l)
+	}
+	return locs
+}
+
+// MayAlias reports whether a and b may point to a common location. Values
+// with no recorded points-to set (e.g. non-pointer values) are conservatively
+// assumed to alias.
+func (r *Result) MayAlias(a, b value.Value) bool {
+	na, ok := r.nodes[a]
+	if !ok {
+		return true
+	}
+	nb, ok := r.nodes[b]
+	if !ok {
+		return true
+	}
+	na, nb = find(na), find(nb)
+	for l := range na.pts {
+		if nb.pts[l] {
+			return true
+		}
+	}
+	return false
+}
+
+// IndirectCallTargets returns the functions that an indirect call
+// instruction's callee may resolve to.
+func (r *Result) IndirectCallTargets(call *ir.InstCall) []*ir.Function {
+	n, ok := r.nodes[call.Callee]
+	if !ok {
+		return nil
+	}
+	n = find(n)
+	var fns []*ir.Function
+	for l := range n.pts {
+		if f, ok := l.Site.(*ir.Function); ok {
+			fns = append(fns, f)
+		}
+	}
+	return fns
+}
+
+// Analyze runs Andersen-style points-to analysis over m and returns the
+// result.
+func Analyze(m *ir.Module) *Result {
+	b := newBuilder()
+	b.addModule(m)
+	b.solve()
+	return &Result{locs: b.locs, nodes: b.nodes, mem: b.mem}
+}
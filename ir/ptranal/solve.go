@@ -0,0 +1,177 @@
+package ptranal
+
+import (
+	"github.com/llir/llvm/ir"
+)
+
+// find returns the canonical representative of n's component, following and
+// compressing the chain of merges recorded by collapseCycle.
+func find(n *node) *node {
+	root := n
+	for root.parent != nil {
+		root = root.parent
+	}
+	for n.parent != nil {
+		next := n.parent
+		n.parent = root
+		n = next
+	}
+	return root
+}
+
+// find is also exposed as a builder method for readability at call sites
+// that already hold a builder.
+func (b *builder) find(n *node) *node {
+	return find(n)
+}
+
+// merge folds n into rep: rep absorbs n's points-to set and outgoing copy
+// edges, and n is left pointing at rep so that every existing reference to n
+// (in b.nodes, b.mem, b.ret, the pending constraint lists, and other nodes'
+// succ sets) resolves to rep the next time it is passed through find.
+func (b *builder) merge(n, rep *node) {
+	if n == rep {
+		return
+	}
+	for l := range n.pts {
+		rep.pts[l] = true
+	}
+	for s := range n.succ {
+		if s := b.find(s); s != rep {
+			rep.succ[s] = true
+		}
+	}
+	n.parent = rep
+	b.worklist = append(b.worklist, rep)
+}
+
+// addCopyNode records a copy edge from -> to (pts(to) ⊇ pts(from)). If the
+// new edge closes a cycle in the subset graph, every node on that cycle is
+// collapsed into a single representative instead (online cycle detection: a
+// depth-first search from to back to from on every new edge; no SCC
+// algorithm, Nuutila's or otherwise, is run over the subset graph): at the
+// fixpoint every node on a propagation cycle carries
+// an identical points-to set, so collapsing it eagerly avoids re-propagating
+// around the same cycle on every worklist dequeue.
+func (b *builder) addCopyNode(from, to *node) {
+	from, to = b.find(from), b.find(to)
+	if from == to {
+		return
+	}
+	if b.reaches(to, from) {
+		b.collapseCycle(from, to)
+		return
+	}
+	from.succ[to] = true
+	b.unionInto(from, to)
+}
+
+// reaches reports whether from can reach to by following succ edges.
+func (b *builder) reaches(from, to *node) bool {
+	visited := map[*node]bool{from: true}
+	stack := []*node{from}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for s := range n.succ {
+			s = b.find(s)
+			if s == to {
+				return true
+			}
+			if !visited[s] {
+				visited[s] = true
+				stack = append(stack, s)
+			}
+		}
+	}
+	return false
+}
+
+// reachableSet returns every node reachable from n via succ edges
+// (including n itself), resolved to representatives.
+func (b *builder) reachableSet(n *node) map[*node]bool {
+	n = b.find(n)
+	visited := map[*node]bool{n: true}
+	stack := []*node{n}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for s := range cur.succ {
+			s = b.find(s)
+			if !visited[s] {
+				visited[s] = true
+				stack = append(stack, s)
+			}
+		}
+	}
+	return visited
+}
+
+// collapseCycle merges every node that lies on some path from to back to
+// from (i.e. every node reachable from to that can itself still reach from)
+// into a single representative, chosen arbitrarily as from: the new from->to
+// edge closes a cycle through exactly these nodes, and at the fixpoint they
+// must all carry the same points-to set.
+func (b *builder) collapseCycle(from, to *node) {
+	for n := range b.reachableSet(to) {
+		if n != from && b.reaches(n, from) {
+			b.merge(n, from)
+		}
+	}
+}
+
+// unionInto propagates from's points-to set into to, re-queuing to if it
+// grew.
+func (b *builder) unionInto(from, to *node) {
+	changed := false
+	for l := range from.pts {
+		if !to.pts[l] {
+			to.pts[l] = true
+			changed = true
+		}
+	}
+	if changed {
+		b.worklist = append(b.worklist, to)
+	}
+}
+
+// solve propagates constraints over the graph until no node's points-to set
+// changes, collapsing cycles in the subset graph on the fly as new copy
+// edges are added (by addCopyNode, called from the load/store/call handling
+// below as well as during graph construction).
+func (b *builder) solve() {
+	for len(b.worklist) > 0 {
+		n := b.find(b.worklist[0])
+		b.worklist = b.worklist[1:]
+
+		for succ := range n.succ {
+			b.unionInto(n, b.find(succ))
+		}
+		for _, c := range b.loads {
+			if b.find(c.p) != n {
+				continue
+			}
+			for l := range n.pts {
+				b.addCopyNode(b.memFor(l), c.q)
+			}
+		}
+		for _, c := range b.stores {
+			if b.find(c.p) != n {
+				continue
+			}
+			for l := range n.pts {
+				b.addCopyNode(c.v, b.memFor(l))
+			}
+		}
+		for _, c := range b.calls {
+			if b.find(c.calleeNode) != n {
+				continue
+			}
+			for l := range n.pts {
+				if f, ok := l.Site.(*ir.Function); ok {
+					b.bindCall(f, c.call)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package ir
+
+import (
+	"fmt"
+)
+
+// TermBr is an LLVM IR unconditional br terminator.
+type TermBr struct {
+	// Target basic block.
+	Target *BasicBlock
+}
+
+// NewBr returns a new unconditional br terminator based on the given target
+// basic block.
+func NewBr(target *BasicBlock) *TermBr {
+	return &TermBr{Target: target}
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (t *TermBr) String() string {
+	return t.Def()
+}
+
+// Def returns the LLVM syntax representation of the terminator definition.
+func (t *TermBr) Def() string {
+	return fmt.Sprintf("br label %s", t.Target.Ident())
+}
+
+// isTerm ensures that only terminators can be assigned to the Terminator
+// interface.
+func (*TermBr) isTerm() {}
+
+// NewBr sets the terminator of the basic block to a new unconditional br
+// terminator based on the given target basic block and returns it.
+func (block *BasicBlock) NewBr(target *BasicBlock) *TermBr {
+	term := NewBr(target)
+	block.Term = term
+	return term
+}
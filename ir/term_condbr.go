@@ -0,0 +1,46 @@
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/value"
+)
+
+// TermCondBr is an LLVM IR conditional br terminator.
+type TermCondBr struct {
+	// Branching condition.
+	Cond value.Value
+	// Target basic block for the true condition.
+	TargetTrue *BasicBlock
+	// Target basic block for the false condition.
+	TargetFalse *BasicBlock
+}
+
+// NewCondBr returns a new conditional br terminator based on the given
+// branching condition and true and false target basic blocks.
+func NewCondBr(cond value.Value, targetTrue, targetFalse *BasicBlock) *TermCondBr {
+	return &TermCondBr{Cond: cond, TargetTrue: targetTrue, TargetFalse: targetFalse}
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (t *TermCondBr) String() string {
+	return t.Def()
+}
+
+// Def returns the LLVM syntax representation of the terminator definition.
+func (t *TermCondBr) Def() string {
+	return fmt.Sprintf("br %s %s, label %s, label %s", t.Cond.Type(), t.Cond.Ident(), t.TargetTrue.Ident(), t.TargetFalse.Ident())
+}
+
+// isTerm ensures that only terminators can be assigned to the Terminator
+// interface.
+func (*TermCondBr) isTerm() {}
+
+// NewCondBr sets the terminator of the basic block to a new conditional br
+// terminator based on the given branching condition and true and false target
+// basic blocks and returns it.
+func (block *BasicBlock) NewCondBr(cond value.Value, targetTrue, targetFalse *BasicBlock) *TermCondBr {
+	term := NewCondBr(cond, targetTrue, targetFalse)
+	block.Term = term
+	return term
+}
@@ -0,0 +1,45 @@
+package ir
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir/value"
+)
+
+// TermRet is an LLVM IR ret terminator.
+type TermRet struct {
+	// Return value; or nil if "ret void".
+	X value.Value
+}
+
+// NewRet returns a new ret terminator based on the given return value. A nil
+// return value indicates a "ret void" terminator.
+func NewRet(x value.Value) *TermRet {
+	return &TermRet{X: x}
+}
+
+// String returns the LLVM syntax representation of the terminator.
+func (t *TermRet) String() string {
+	return t.Def()
+}
+
+// Def returns the LLVM syntax representation of the terminator definition.
+func (t *TermRet) Def() string {
+	if t.X == nil {
+		return "ret void"
+	}
+	return fmt.Sprintf("ret %s %s", t.X.Type(), t.X.Ident())
+}
+
+// isTerm ensures that only terminators can be assigned to the Terminator
+// interface.
+func (*TermRet) isTerm() {}
+
+// NewRet sets the terminator of the basic block to a new ret terminator based
+// on the given return value and returns it. A nil return value indicates a
+// "ret void" terminator.
+func (block *BasicBlock) NewRet(x value.Value) *TermRet {
+	term := NewRet(x)
+	block.Term = term
+	return term
+}
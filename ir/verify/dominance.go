@@ -0,0 +1,129 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/dom"
+	"github.com/llir/llvm/ir/value"
+)
+
+// pos identifies the position of a definition or use within f: the basic
+// block it belongs to and its index within that block's instruction list, or
+// -1 for a value defined by the terminator of its block (unused by this
+// package, but kept for symmetry) or by the function's parameter list.
+type pos struct {
+	block *ir.BasicBlock
+	index int
+}
+
+// verifyDominance checks that every use of a local value is dominated by its
+// definition. Phi incoming values are the one exception: an incoming value
+// is used at the end of the corresponding predecessor block rather than at
+// the phi instruction itself, and so need only dominate that predecessor.
+func verifyDominance(f *ir.Function) []error {
+	if len(f.Blocks) == 0 {
+		return nil
+	}
+	tree := dom.Build(f)
+
+	defPos := make(map[value.Value]pos, len(f.Params))
+	for _, param := range f.Params {
+		// A parameter dominates every block of f.
+		defPos[param] = pos{block: f.Blocks[0], index: -1}
+	}
+	for _, b := range f.Blocks {
+		for i, inst := range b.Insts {
+			// Instructions without a result (e.g. store) do not implement
+			// value.Value and so cannot be used; nothing to record.
+			if v, ok := inst.(value.Value); ok {
+				defPos[v] = pos{block: b, index: i}
+			}
+		}
+	}
+
+	dominates := func(def pos, useBlock *ir.BasicBlock, useIndex int) bool {
+		if def.block == useBlock {
+			return def.index < useIndex
+		}
+		return tree.Dominates(def.block, useBlock)
+	}
+
+	var errs []error
+	// context is only called when a violation is reported, as Def() panics
+	// for some malformed instructions (e.g. a call with an invalid callee).
+	check := func(v value.Value, useBlock *ir.BasicBlock, useIndex int, context func() string) {
+		def, ok := defPos[v]
+		if !ok {
+			// Not a value defined within f (e.g. a global or constant);
+			// nothing to check.
+			return
+		}
+		if !dominates(def, useBlock, useIndex) {
+			errs = append(errs, fmt.Errorf("%s: use of %s not dominated by its definition", context(), v.Ident()))
+		}
+	}
+
+	for _, b := range f.Blocks {
+		for i, inst := range b.Insts {
+			if phi, ok := inst.(*ir.InstPhi); ok {
+				// phi.Def() is unsafe here: InstPhi.Type() (which Def() calls
+				// to format the result) panics when Incs is empty. Use
+				// phi.Ident() instead, which does not depend on Incs.
+				for _, inc := range phi.Incs {
+					check(inc.X, inc.Pred, len(inc.Pred.Insts), phi.Ident)
+				}
+				continue
+			}
+			for _, v := range instUses(inst) {
+				check(v, b, i, inst.Def)
+			}
+		}
+		if b.Term != nil {
+			for _, v := range termUses(b.Term) {
+				check(v, b, len(b.Insts), b.Term.Def)
+			}
+		}
+	}
+	return errs
+}
+
+// instUses returns the operands read by inst, excluding phi instructions,
+// whose incoming values are checked separately against their predecessor
+// blocks rather than the phi's own position.
+func instUses(inst ir.Instruction) []value.Value {
+	switch inst := inst.(type) {
+	case *ir.InstAdd:
+		return []value.Value{inst.X, inst.Y}
+	case *ir.InstLoad:
+		return []value.Value{inst.Src}
+	case *ir.InstStore:
+		return []value.Value{inst.Src, inst.Dst}
+	case *ir.InstGetElementPtr:
+		return append([]value.Value{inst.Src}, inst.Indices...)
+	case *ir.InstCall:
+		return append([]value.Value{inst.Callee}, inst.Args...)
+	case *ir.InstAlloca:
+		if inst.NElems != nil {
+			return []value.Value{inst.NElems}
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// termUses returns the value operands read by term.
+func termUses(term ir.Terminator) []value.Value {
+	switch term := term.(type) {
+	case *ir.TermRet:
+		if term.X != nil {
+			return []value.Value{term.X}
+		}
+		return nil
+	case *ir.TermCondBr:
+		return []value.Value{term.Cond}
+	default:
+		return nil
+	}
+}
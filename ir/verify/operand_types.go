@@ -0,0 +1,126 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/types"
+)
+
+// verifyOperandTypes checks the operand type constraints of every
+// instruction of f, and that the type of every ret terminator matches
+// f.Sig.RetType.
+func verifyOperandTypes(f *ir.Function) []error {
+	var errs []error
+	for _, b := range f.Blocks {
+		for _, inst := range b.Insts {
+			errs = append(errs, verifyInstOperands(inst)...)
+		}
+		if ret, ok := b.Term.(*ir.TermRet); ok {
+			errs = append(errs, verifyRet(f, ret)...)
+		}
+	}
+	return errs
+}
+
+// verifyInstOperands checks the operand type constraints specific to the
+// opcode of inst.
+func verifyInstOperands(inst ir.Instruction) []error {
+	var errs []error
+	switch inst := inst.(type) {
+	case *ir.InstAdd:
+		if !inst.X.Type().Equal(inst.Y.Type()) {
+			errs = append(errs, fmt.Errorf("%s: operand types do not match; expected %s, got %s", inst.Def(), inst.X.Type(), inst.Y.Type()))
+		} else if !isIntOrIntVector(inst.X.Type()) {
+			errs = append(errs, fmt.Errorf("%s: invalid operand type %s; expected integer or vector of integers", inst.Def(), inst.X.Type()))
+		}
+	case *ir.InstGetElementPtr:
+		for _, index := range inst.Indices {
+			if !isIntOrIntVector(index.Type()) {
+				errs = append(errs, fmt.Errorf("%s: invalid index type %s; expected integer", inst.Def(), index.Type()))
+			}
+		}
+	case *ir.InstLoad:
+		if _, ok := inst.Src.Type().(*types.PointerType); !ok {
+			errs = append(errs, fmt.Errorf("%s: invalid source type %s; expected pointer", inst.Def(), inst.Src.Type()))
+		}
+	case *ir.InstStore:
+		dstType, ok := inst.Dst.Type().(*types.PointerType)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: invalid destination type %s; expected pointer", inst.Def(), inst.Dst.Type()))
+		} else if !dstType.ElemType.Equal(inst.Src.Type()) {
+			errs = append(errs, fmt.Errorf("%s: destination element type %s does not match source type %s", inst.Def(), dstType.ElemType, inst.Src.Type()))
+		}
+	case *ir.InstCall:
+		errs = append(errs, verifyCall(inst)...)
+	}
+	return errs
+}
+
+// verifyCall checks that the arguments of inst match the signature of its
+// callee in count (accounting for varargs) and type.
+func verifyCall(inst *ir.InstCall) []error {
+	sig := calleeSigOf(inst)
+	if sig == nil {
+		// inst.Def() is unsafe here: InstCall.Type() (which Def() calls to
+		// format the result) panics on exactly the invalid-callee case being
+		// reported, so format the diagnostic from inst.Ident() instead.
+		return []error{fmt.Errorf("%s: invalid callee type %s; expected function or pointer to function", inst.Ident(), inst.Callee.Type())}
+	}
+	if len(inst.Args) < len(sig.Params) {
+		return []error{fmt.Errorf("%s: too few arguments; expected at least %d, got %d", inst.Def(), len(sig.Params), len(inst.Args))}
+	}
+	if len(inst.Args) > len(sig.Params) && !sig.Variadic {
+		return []error{fmt.Errorf("%s: too many arguments; expected %d, got %d", inst.Def(), len(sig.Params), len(inst.Args))}
+	}
+	var errs []error
+	for i, param := range sig.Params {
+		if !inst.Args[i].Type().Equal(param) {
+			errs = append(errs, fmt.Errorf("%s: argument %d type %s does not match parameter type %s", inst.Def(), i, inst.Args[i].Type(), param))
+		}
+	}
+	return errs
+}
+
+// calleeSigOf returns the function signature of inst's callee, or nil if the
+// callee does not have a function or pointer-to-function type.
+func calleeSigOf(inst *ir.InstCall) *types.FuncType {
+	switch t := inst.Callee.Type().(type) {
+	case *types.FuncType:
+		return t
+	case *types.PointerType:
+		if sig, ok := t.ElemType.(*types.FuncType); ok {
+			return sig
+		}
+	}
+	return nil
+}
+
+// verifyRet checks that the type of ret matches f.Sig.RetType.
+func verifyRet(f *ir.Function, ret *ir.TermRet) []error {
+	retType := f.Sig.RetType
+	if ret.X == nil {
+		if !retType.Equal(types.Void) {
+			return []error{fmt.Errorf("%s: missing return value; expected %s", ret.Def(), retType)}
+		}
+		return nil
+	}
+	if !ret.X.Type().Equal(retType) {
+		return []error{fmt.Errorf("%s: return type %s does not match function signature %s", ret.Def(), ret.X.Type(), retType)}
+	}
+	return nil
+}
+
+// isIntOrIntVector reports whether t is an integer type or a vector of
+// integer type.
+func isIntOrIntVector(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.IntType:
+		return true
+	case *types.VectorType:
+		_, ok := t.ElemType.(*types.IntType)
+		return ok
+	default:
+		return false
+	}
+}
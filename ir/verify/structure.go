@@ -0,0 +1,63 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/llir/llvm/ir"
+)
+
+// verifyTerminators checks that every basic block of f ends in exactly one
+// terminator.
+func verifyTerminators(f *ir.Function) []error {
+	var errs []error
+	for _, b := range f.Blocks {
+		if b.Term == nil {
+			errs = append(errs, fmt.Errorf("basic block %s has no terminator", b.Ident()))
+		}
+	}
+	return errs
+}
+
+// verifyPhis checks that the incoming blocks of every phi instruction of f
+// exactly match the predecessor set of its parent block, as computed from
+// terminators.
+func verifyPhis(f *ir.Function) []error {
+	var errs []error
+	// Compute every block's predecessor set in one pass over the terminators,
+	// rather than calling f.Predecessors (itself a scan of f) once per block.
+	predsOf := make(map[*ir.BasicBlock]map[*ir.BasicBlock]bool, len(f.Blocks))
+	for _, p := range f.Blocks {
+		for _, s := range f.Successors(p) {
+			if predsOf[s] == nil {
+				predsOf[s] = make(map[*ir.BasicBlock]bool)
+			}
+			predsOf[s][p] = true
+		}
+	}
+	for _, b := range f.Blocks {
+		preds := predsOf[b]
+		for _, inst := range b.Insts {
+			phi, ok := inst.(*ir.InstPhi)
+			if !ok {
+				continue
+			}
+			// phi.Def() is unsafe here: InstPhi.Type() (which Def() calls to
+			// format the result) panics when Incs is empty, which is exactly
+			// the malformed case this check exists to report. Use phi.Ident()
+			// instead, which does not depend on Incs being non-empty.
+			incoming := make(map[*ir.BasicBlock]bool)
+			for _, inc := range phi.Incs {
+				if !preds[inc.Pred] {
+					errs = append(errs, fmt.Errorf("%s: incoming block %s is not a predecessor of %s", phi.Ident(), inc.Pred.Ident(), b.Ident()))
+				}
+				incoming[inc.Pred] = true
+			}
+			for p := range preds {
+				if !incoming[p] {
+					errs = append(errs, fmt.Errorf("%s: missing incoming value for predecessor %s", phi.Ident(), p.Ident()))
+				}
+			}
+		}
+	}
+	return errs
+}
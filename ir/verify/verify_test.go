@@ -0,0 +1,235 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/llir/llvm/ir"
+	"github.com/llir/llvm/ir/constant"
+	"github.com/llir/llvm/ir/types"
+)
+
+func wantErr(t *testing.T, err error, substr string) {
+	t.Helper()
+	if err == nil {
+		t.Fatalf("want error containing %q, got nil", substr)
+	}
+	if !strings.Contains(err.Error(), substr) {
+		t.Fatalf("error = %q, want it to contain %q", err.Error(), substr)
+	}
+}
+
+func wantOK(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("want no error, got %q", err.Error())
+	}
+}
+
+// --- verifyTerminators ---
+
+func TestVerifyTerminatorsOK(t *testing.T) {
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry").NewRet(nil)
+	wantOK(t, VerifyFunction(f))
+}
+
+func TestVerifyTerminatorsMissing(t *testing.T) {
+	f := ir.NewFunction("f", types.Void)
+	f.NewBlock("entry")
+	wantErr(t, VerifyFunction(f), "has no terminator")
+}
+
+// --- verifyPhis ---
+
+func TestVerifyPhisOK(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	f := ir.NewFunction("f", types.I32, cond, x)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewBr(end)
+	elseB.NewBr(end)
+	phi := end.NewPhi(ir.NewIncoming(x, thenB), ir.NewIncoming(x, elseB))
+	end.NewRet(phi)
+	wantOK(t, VerifyFunction(f))
+}
+
+func TestVerifyPhisMissingIncoming(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	f := ir.NewFunction("f", types.I32, cond, x)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewBr(end)
+	elseB.NewBr(end)
+	// Missing the incoming value for elseB.
+	phi := end.NewPhi(ir.NewIncoming(x, thenB))
+	end.NewRet(phi)
+	wantErr(t, VerifyFunction(f), "missing incoming value")
+}
+
+func TestVerifyPhisNotAPredecessor(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	f := ir.NewFunction("f", types.I32, cond, x)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	other := f.NewBlock("other")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewBr(end)
+	elseB.NewBr(end)
+	other.NewRet(nil)
+	// other is not a predecessor of end.
+	phi := end.NewPhi(ir.NewIncoming(x, thenB), ir.NewIncoming(x, elseB), ir.NewIncoming(x, other))
+	end.NewRet(phi)
+	wantErr(t, VerifyFunction(f), "is not a predecessor")
+}
+
+func TestVerifyPhisEmptyIncoming(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	f := ir.NewFunction("f", types.Void, cond)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	thenB.NewBr(end)
+	elseB.NewBr(end)
+	// A phi built incrementally via the builder API (e.g. end.NewPhi()
+	// followed by later appends to Incs) has no incoming values yet; this
+	// must be reported rather than panic when formatting the diagnostic.
+	end.NewPhi()
+	end.NewRet(nil)
+	wantErr(t, VerifyFunction(f), "missing incoming value")
+}
+
+// --- verifyOperandTypes ---
+
+func TestVerifyOperandTypesOK(t *testing.T) {
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("f", types.I32, x, y)
+	entry := f.NewBlock("entry")
+	a := entry.NewAdd(x, y)
+	entry.NewRet(a)
+	wantOK(t, VerifyFunction(f))
+}
+
+func TestVerifyOperandTypesAddMismatch(t *testing.T) {
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I64)
+	f := ir.NewFunction("f", types.I32, x, y)
+	entry := f.NewBlock("entry")
+	a := entry.NewAdd(x, y)
+	entry.NewRet(a)
+	wantErr(t, VerifyFunction(f), "operand types do not match")
+}
+
+func TestVerifyOperandTypesStoreDstNotPointer(t *testing.T) {
+	x := ir.NewParam("x", types.I32)
+	y := ir.NewParam("y", types.I32)
+	f := ir.NewFunction("f", types.Void, x, y)
+	entry := f.NewBlock("entry")
+	entry.NewStore(x, y)
+	entry.NewRet(nil)
+	wantErr(t, VerifyFunction(f), "invalid destination type")
+}
+
+func TestVerifyOperandTypesInvalidCallee(t *testing.T) {
+	// callee is an i32 value, neither a function nor a pointer to function;
+	// this must be reported rather than panic when formatting the
+	// diagnostic (InstCall.Def() cannot be used, since it calls Type(),
+	// which itself panics for an invalid callee).
+	callee := ir.NewParam("callee", types.I32)
+	f := ir.NewFunction("f", types.Void, callee)
+	entry := f.NewBlock("entry")
+	entry.NewCall(callee)
+	entry.NewRet(nil)
+	wantErr(t, VerifyFunction(f), "invalid callee type")
+}
+
+func TestVerifyOperandTypesRetMismatch(t *testing.T) {
+	x := ir.NewParam("x", types.I32)
+	f := ir.NewFunction("f", types.I64, x)
+	entry := f.NewBlock("entry")
+	entry.NewRet(x)
+	wantErr(t, VerifyFunction(f), "does not match function signature")
+}
+
+// --- verifyDominance ---
+
+func TestVerifyDominanceOK(t *testing.T) {
+	x := ir.NewParam("x", types.I32)
+	f := ir.NewFunction("f", types.I32, x)
+	entry := f.NewBlock("entry")
+	exit := f.NewBlock("exit")
+	a := entry.NewAdd(x, x)
+	entry.NewBr(exit)
+	exit.NewRet(a)
+	wantOK(t, VerifyFunction(f))
+}
+
+func TestVerifyDominanceUseNotDominated(t *testing.T) {
+	cond := ir.NewParam("cond", types.I1)
+	x := ir.NewParam("x", types.I32)
+	f := ir.NewFunction("f", types.I32, cond, x)
+	entry := f.NewBlock("entry")
+	thenB := f.NewBlock("then")
+	elseB := f.NewBlock("else")
+	end := f.NewBlock("end")
+	entry.NewCondBr(cond, thenB, elseB)
+	a := thenB.NewAdd(x, x)
+	thenB.NewBr(end)
+	elseB.NewBr(end)
+	// a is only defined on the then-edge, but used unconditionally in end
+	// (not behind a phi), so this use is not dominated by its definition.
+	end.NewRet(a)
+	wantErr(t, VerifyFunction(f), "not dominated by its definition")
+}
+
+// --- verifyUniqueIdents (module-wide) ---
+
+func TestVerifyUniqueIdentsOK(t *testing.T) {
+	f1 := ir.NewFunction("f1", types.Void)
+	f1.NewBlock("entry").NewRet(nil)
+	f2 := ir.NewFunction("f2", types.Void)
+	f2.NewBlock("entry").NewRet(nil)
+	m := &ir.Module{Funcs: []*ir.Function{f1, f2}}
+	wantOK(t, Verify(m))
+}
+
+func TestVerifyUniqueIdentsDuplicate(t *testing.T) {
+	f1 := ir.NewFunction("f", types.Void)
+	f1.NewBlock("entry").NewRet(nil)
+	f2 := ir.NewFunction("f", types.Void)
+	f2.NewBlock("entry").NewRet(nil)
+	m := &ir.Module{Funcs: []*ir.Function{f1, f2}}
+	wantErr(t, Verify(m), "duplicate global identifier")
+}
+
+// --- verifyAliases ---
+
+func TestVerifyAliasesOK(t *testing.T) {
+	g := &ir.Global{ContentType: types.I32, Typ: types.NewPointer(types.I32)}
+	g.SetName("g")
+	a := ir.NewAlias("a", g)
+	m := &ir.Module{Globals: []*ir.Global{g}, Aliases: []*ir.Alias{a}}
+	wantOK(t, Verify(m))
+}
+
+func TestVerifyAliasesAliaseeNotPointer(t *testing.T) {
+	// A plain i32 constant is not a valid aliasee; aliases must alias
+	// something of pointer type.
+	a := &ir.Alias{Aliasee: constant.NewInt(types.I32, 0)}
+	m := &ir.Module{Aliases: []*ir.Alias{a}}
+	wantErr(t, Verify(m), "is not a pointer type")
+}